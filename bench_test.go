@@ -0,0 +1,178 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+var (
+	benchFactory = NewNotFoundFactory("user %d not found")
+	benchSink    error
+	benchString  string
+	benchBool    bool
+	benchKind    Kind
+)
+
+func BenchmarkStdNew(b *testing.B) {
+	b.ReportAllocs()
+
+	for b.Loop() {
+		benchSink = stderrors.New("user not found")
+	}
+}
+
+func BenchmarkFmtErrorf(b *testing.B) {
+	b.ReportAllocs()
+
+	for b.Loop() {
+		benchSink = fmt.Errorf("user %d not found", 42)
+	}
+}
+
+func BenchmarkFmtErrorfWrap(b *testing.B) {
+	var cause = stderrors.New("no rows")
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		benchSink = fmt.Errorf("user %d not found: %w", 42, cause)
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	b.ReportAllocs()
+
+	for b.Loop() {
+		benchSink = New(ErrKindNotFound, "user not found")
+	}
+}
+
+func BenchmarkNewArgs(b *testing.B) {
+	b.ReportAllocs()
+
+	for b.Loop() {
+		benchSink = New(ErrKindNotFound, "user %d not found", 42)
+	}
+}
+
+func BenchmarkNewCtx(b *testing.B) {
+	var ctx = WithRequestId(context.Background(), "kek")
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		benchSink = NewCtx(ctx, ErrKindNotFound, "user not found")
+	}
+}
+
+func BenchmarkFactoryNew(b *testing.B) {
+	b.ReportAllocs()
+
+	for b.Loop() {
+		benchSink = benchFactory.New(42)
+	}
+}
+
+func BenchmarkWrap(b *testing.B) {
+	var cause = stderrors.New("no rows")
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		benchSink = benchFactory.New(42).Wrap(cause)
+	}
+}
+
+func BenchmarkError(b *testing.B) {
+	var err = benchFactory.New(42).Wrap(NewInfrastructureFactory("db is down").New())
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		benchString = err.Error()
+	}
+}
+
+func BenchmarkStdError(b *testing.B) {
+	var err = fmt.Errorf("user %d not found: %w", 42, stderrors.New("db is down"))
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		benchString = err.Error()
+	}
+}
+
+func BenchmarkLocation(b *testing.B) {
+	var err = benchFactory.New(42).(*implementation)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		benchString = err.Location()
+	}
+}
+
+func BenchmarkStackTrace(b *testing.B) {
+	var err = benchFactory.New(42).Wrap(NewInfrastructureFactory("db is down").New()).(*implementation)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		benchSink = stderrors.New(string(err.StackTrace()))
+	}
+}
+
+func BenchmarkKindOf(b *testing.B) {
+	var err = benchFactory.New(42)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		benchString = KindOf(err).String()
+	}
+}
+
+func BenchmarkParseKind(b *testing.B) {
+	b.ReportAllocs()
+
+	for b.Loop() {
+		benchKind = ParseKind("NotFound")
+	}
+}
+
+// TestAllocations keeps allocation budgets documented in doc.go.
+func (suite *ErrorsSuite) TestAllocations() {
+	// budgets are those of programs with no helpers, see Helper
+	defer helperCount.Store(helperCount.Swap(0))
+
+	// the first garbage collection starts its workers, their allocations must not be counted
+	runtime.GC()
+
+	var (
+		cause   = stderrors.New("no rows")
+		wrapper = benchFactory.New(42)
+		err     = benchFactory.New(42).Wrap(NewInfrastructureFactory("db is down").New())
+	)
+
+	var budgets = []struct {
+		name   string
+		allocs float64
+		fn     func()
+	}{
+		{name: "New", allocs: 1, fn: func() { benchSink = New(ErrKindNotFound, "user not found") }},
+		{name: "New with arguments", allocs: 2, fn: func() { benchSink = New(ErrKindNotFound, "user %d not found", 42) }},
+		{name: "Factory.New", allocs: 3, fn: func() { benchSink = benchFactory.New(42) }},
+		{name: "Wrap", allocs: 0, fn: func() { benchSink = wrapper.Wrap(cause) }},
+		{name: "Error", allocs: 0, fn: func() { benchString = err.Error() }},
+		{name: "Kind.String", allocs: 0, fn: func() { benchString = KindOf(err).String() }},
+		{name: "ParseKind", allocs: 0, fn: func() { benchKind = ParseKind("NotFound") }},
+	}
+
+	for _, b := range budgets {
+		suite.Require().LessOrEqual(testing.AllocsPerRun(100, b.fn), b.allocs, b.name)
+	}
+}
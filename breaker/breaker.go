@@ -0,0 +1,258 @@
+// Package breaker implements a circuit breaker driven by error kinds.
+// Only errors indicating a dependency fault are counted, client faults never trip a breaker.
+package breaker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aerario/errors"
+)
+
+const (
+	DetailDependency = "dependency"
+	DetailRetryAfter = errors.DetailRetryAfter
+)
+
+// ErrOpen is returned instead of calling a dependency while its breaker is open.
+// Its details contain the dependency name and the remaining cool-down.
+var ErrOpen = errors.WithCode(errors.NewInfrastructureFactory("circuit breaker %q is open"), "CIRCUIT-OPEN")
+
+type State int
+
+const (
+	StateClosed State = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (self State) String() string {
+	switch self {
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	}
+
+	return "closed"
+}
+
+// Settings configure breakers, zero values are replaced with defaults.
+type Settings struct {
+	// Threshold is a number of consecutive failures which opens the breaker, 5 by default.
+	Threshold int
+	// CoolDown is a time the breaker stays open for, 30 seconds by default.
+	CoolDown time.Duration
+	// HalfOpenCalls is a number of trial calls in half-open state, all of them must succeed to close the breaker.
+	HalfOpenCalls int
+	// IsFailure reports whether an error is a failure of the dependency, IsDependencyFault by default.
+	IsFailure func(error) bool
+	// Now is a clock, time.Now by default.
+	Now func() time.Time
+}
+
+func (self Settings) withDefaults() Settings {
+	if self.Threshold <= 0 {
+		self.Threshold = 5
+	}
+
+	if self.CoolDown <= 0 {
+		self.CoolDown = 30 * time.Second
+	}
+
+	if self.HalfOpenCalls <= 0 {
+		self.HalfOpenCalls = 1
+	}
+
+	if self.IsFailure == nil {
+		self.IsFailure = IsDependencyFault
+	}
+
+	if self.Now == nil {
+		self.Now = time.Now
+	}
+
+	return self
+}
+
+// IsDependencyFault reports whether the error is caused by a dependency:
+// ThirdParties, Infrastructure and Timeout errors along with retryable Persistence errors.
+func IsDependencyFault(err error) bool {
+	switch errors.KindOf(err) {
+	case errors.ErrKindThirdParties, errors.ErrKindInfrastructure, errors.ErrKindTimeout:
+		return true
+	case errors.ErrKindPersistence:
+		return errors.IsRetryable(err)
+	}
+
+	return false
+}
+
+// Breaker guards calls of a single dependency.
+type Breaker struct {
+	name     string
+	settings Settings
+
+	mu         sync.Mutex
+	state      State
+	generation uint64 // incremented on state changes, results of calls admitted before are ignored
+	failures   int
+	openedAt   time.Time
+	inFlight   int
+	successes  int
+}
+
+func New(name string, settings Settings) *Breaker {
+	return &Breaker{
+		name:     name,
+		settings: settings.withDefaults(),
+	}
+}
+
+func (self *Breaker) Name() string {
+	return self.name
+}
+
+// State returns the current state, an open breaker becomes half-open once the cool-down passes.
+func (self *Breaker) State() State {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.state == StateOpen && self.remaining() <= 0 {
+		return StateHalfOpen
+	}
+
+	return self.state
+}
+
+// Do calls fn unless the breaker is open, panics of fn are converted into errors.
+func (self *Breaker) Do(ctx context.Context, fn func(context.Context) error) error {
+	var generation, err = self.allow()
+	if err != nil {
+		return err
+	}
+
+	err = errors.Catch(func() error { return fn(ctx) })
+	self.record(generation, err)
+
+	return err
+}
+
+// allow admits a call, the returned generation is the one the call is admitted under.
+func (self *Breaker) allow() (uint64, error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.state == StateOpen {
+		if self.remaining() > 0 {
+			return 0, self.openError()
+		}
+
+		self.transition(StateHalfOpen)
+		self.inFlight = 0
+		self.successes = 0
+	}
+
+	if self.state == StateHalfOpen {
+		if self.inFlight+self.successes >= self.settings.HalfOpenCalls {
+			return 0, self.openError()
+		}
+
+		self.inFlight++
+	}
+
+	return self.generation, nil
+}
+
+// record counts a result of a call, calls admitted under another state tell nothing about the current one.
+func (self *Breaker) record(generation uint64, err error) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if generation != self.generation {
+		return
+	}
+
+	var failure = err != nil && self.settings.IsFailure(err)
+
+	switch self.state {
+	case StateHalfOpen:
+		self.inFlight--
+
+		if failure {
+			self.open()
+			return
+		}
+
+		if self.successes++; self.successes >= self.settings.HalfOpenCalls {
+			self.transition(StateClosed)
+			self.failures = 0
+		}
+	case StateClosed:
+		if !failure {
+			self.failures = 0
+			return
+		}
+
+		if self.failures++; self.failures >= self.settings.Threshold {
+			self.open()
+		}
+	}
+}
+
+func (self *Breaker) transition(state State) {
+	self.state = state
+	self.generation++
+}
+
+func (self *Breaker) open() {
+	self.transition(StateOpen)
+	self.openedAt = self.settings.Now()
+	self.failures = 0
+}
+
+func (self *Breaker) remaining() time.Duration {
+	return self.settings.CoolDown - self.settings.Now().Sub(self.openedAt)
+}
+
+func (self *Breaker) openError() errors.Error {
+	return ErrOpen.New(self.name).WithDetails(map[string]string{
+		DetailDependency: self.name,
+		DetailRetryAfter: max(self.remaining(), 0).String(),
+	})
+}
+
+// Set keeps a breaker per named dependency, all of them share the same settings.
+type Set struct {
+	settings Settings
+
+	mu       sync.Mutex
+	breakers map[string]*Breaker
+}
+
+func NewSet(settings Settings) *Set {
+	return &Set{
+		settings: settings,
+		breakers: make(map[string]*Breaker),
+	}
+}
+
+// Get returns a breaker of the dependency creating it if necessary.
+func (self *Set) Get(name string) *Breaker {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	var b, ok = self.breakers[name]
+	if !ok {
+		b = New(name, self.settings)
+		self.breakers[name] = b
+	}
+
+	return b
+}
+
+// Do calls fn through the breaker of the dependency.
+func (self *Set) Do(ctx context.Context, name string, fn func(context.Context) error) error {
+	return self.Get(name).Do(ctx, fn)
+}
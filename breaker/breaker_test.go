@@ -0,0 +1,216 @@
+package breaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/aerario/errors"
+)
+
+type BreakerSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func TestBreakerSuite(t *testing.T) {
+	suite.Run(t, new(BreakerSuite))
+}
+
+func (suite *BreakerSuite) SetupTest() {
+	suite.now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (suite *BreakerSuite) newBreaker() *Breaker {
+	return New("db", Settings{
+		Threshold:     3,
+		CoolDown:      10 * time.Second,
+		HalfOpenCalls: 2,
+		Now:           func() time.Time { return suite.now },
+	})
+}
+
+func fail(err error) func(context.Context) error {
+	return func(context.Context) error { return err }
+}
+
+func (suite *BreakerSuite) TestIsDependencyFault() {
+	var tests = []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "third parties", err: errors.NewThirdPartiesError("kek"), want: true},
+		{name: "infrastructure", err: errors.NewInfrastructureError("kek"), want: true},
+		{name: "timeout", err: errors.NewTimeoutError("kek"), want: true},
+		{name: "persistence", err: errors.NewPersistenceError("kek"), want: false},
+		{name: "retryable persistence", err: errors.NewPersistenceError("kek").WithLabels(errors.LabelRetryable), want: true},
+		{name: "validation", err: errors.NewValidationError("kek"), want: false},
+		{name: "not found", err: errors.NewNotFoundError("kek"), want: false},
+		{name: "already exists", err: errors.NewAlreadyExistsError("kek"), want: false},
+	}
+
+	for _, t := range tests {
+		suite.Run(t.name, func() {
+			suite.Require().Equal(t.want, IsDependencyFault(t.err))
+		})
+	}
+}
+
+func (suite *BreakerSuite) TestClientFaults() {
+	var (
+		b   = suite.newBreaker()
+		ctx = context.Background()
+	)
+
+	for range 10 {
+		suite.Require().Error(b.Do(ctx, fail(errors.NewValidationError("invalid"))))
+		suite.Require().Error(b.Do(ctx, fail(errors.NewNotFoundError("not found"))))
+	}
+
+	suite.Require().Equal(StateClosed, b.State())
+}
+
+func (suite *BreakerSuite) TestConsecutiveFailures() {
+	var (
+		b       = suite.newBreaker()
+		ctx     = context.Background()
+		timeout = errors.NewTimeoutError("timed out")
+	)
+
+	suite.Require().Equal(timeout, b.Do(ctx, fail(timeout)))
+	suite.Require().Equal(timeout, b.Do(ctx, fail(timeout)))
+	suite.Require().NoError(b.Do(ctx, fail(nil)))
+	suite.Require().Equal(timeout, b.Do(ctx, fail(timeout)))
+	suite.Require().Equal(timeout, b.Do(ctx, fail(timeout)))
+	suite.Require().Equal(StateClosed, b.State())
+}
+
+func (suite *BreakerSuite) TestOpen() {
+	var (
+		b       = suite.newBreaker()
+		ctx     = context.Background()
+		timeout = errors.NewTimeoutError("timed out")
+		calls   int
+	)
+
+	for range 3 {
+		suite.Require().Equal(timeout, b.Do(ctx, fail(timeout)))
+	}
+
+	suite.Require().Equal(StateOpen, b.State())
+
+	suite.now = suite.now.Add(4 * time.Second)
+
+	var err = b.Do(ctx, func(context.Context) error {
+		calls++
+		return nil
+	})
+	suite.Require().Zero(calls)
+	suite.Require().True(errors.Is(err, ErrOpen))
+	suite.Require().Equal(errors.ErrKindInfrastructure, errors.KindOf(err))
+	suite.Require().Equal(map[string]string{DetailDependency: "db", DetailRetryAfter: "6s"}, errors.From(err).Details())
+
+	suite.now = suite.now.Add(6 * time.Second)
+	suite.Require().Equal(StateHalfOpen, b.State())
+}
+
+func (suite *BreakerSuite) TestHalfOpen() {
+	var (
+		b       = suite.newBreaker()
+		ctx     = context.Background()
+		timeout = errors.NewTimeoutError("timed out")
+	)
+
+	for range 3 {
+		_ = b.Do(ctx, fail(timeout))
+	}
+
+	// a failed trial call opens the breaker again
+	suite.now = suite.now.Add(10 * time.Second)
+	suite.Require().Equal(timeout, b.Do(ctx, fail(timeout)))
+	suite.Require().Equal(StateOpen, b.State())
+
+	suite.now = suite.now.Add(10 * time.Second)
+	suite.Require().NoError(b.Do(ctx, fail(nil)))
+	suite.Require().Equal(StateHalfOpen, b.State())
+
+	// trial calls are limited while in flight
+	var err = b.Do(ctx, func(context.Context) error {
+		suite.Require().True(errors.Is(b.Do(ctx, fail(nil)), ErrOpen))
+		return nil
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(StateClosed, b.State())
+}
+
+func (suite *BreakerSuite) TestStaleCall() {
+	var (
+		b       = suite.newBreaker()
+		ctx     = context.Background()
+		timeout = errors.NewTimeoutError("timed out")
+	)
+
+	var call = func() (started chan struct{}, release chan error, done chan error) {
+		started, release, done = make(chan struct{}), make(chan error), make(chan error)
+
+		go func() {
+			done <- b.Do(ctx, func(context.Context) error {
+				close(started)
+				return <-release
+			})
+		}()
+
+		return started, release, done
+	}
+
+	// a call admitted while the breaker is closed finishes after it is half-open
+	var started, releaseSlow, slowDone = call()
+	<-started
+
+	for range 3 {
+		_ = b.Do(ctx, fail(timeout))
+	}
+
+	suite.now = suite.now.Add(10 * time.Second)
+
+	var releaseTrial, trialDone chan error
+	started, releaseTrial, trialDone = call()
+	<-started
+
+	releaseSlow <- nil
+	suite.Require().NoError(<-slowDone)
+
+	b.mu.Lock()
+	suite.Require().Equal(1, b.inFlight)
+	suite.Require().Zero(b.successes)
+	b.mu.Unlock()
+
+	releaseTrial <- nil
+	suite.Require().NoError(<-trialDone)
+	suite.Require().Equal(StateHalfOpen, b.State())
+}
+
+func (suite *BreakerSuite) TestPanic() {
+	var b = suite.newBreaker()
+
+	var err = b.Do(context.Background(), func(context.Context) error { panic("kek") })
+	suite.Require().True(errors.Labels(err).Has(errors.LabelPanic))
+	suite.Require().Equal(StateClosed, b.State())
+}
+
+func (suite *BreakerSuite) TestSet() {
+	var (
+		set = NewSet(Settings{Threshold: 1, Now: func() time.Time { return suite.now }})
+		ctx = context.Background()
+	)
+
+	suite.Require().Same(set.Get("db"), set.Get("db"))
+
+	_ = set.Do(ctx, "db", fail(errors.NewInfrastructureError("no connection")))
+	suite.Require().Equal(StateOpen, set.Get("db").State())
+	suite.Require().Equal(StateClosed, set.Get("queue").State())
+	suite.Require().NoError(set.Do(ctx, "queue", fail(nil)))
+}
@@ -0,0 +1,6 @@
+//go:build !errors_nostack
+
+package errors
+
+// stackCapture is turned off by errors_nostack build tag, errors get neither locations nor stacks then.
+const stackCapture = true
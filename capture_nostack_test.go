@@ -0,0 +1,17 @@
+//go:build errors_nostack
+
+package errors
+
+func (suite *ErrorsSuite) TestNoStackCapture() {
+	defer SetStackPolicy(StackDefault)
+
+	SetStackPolicy(StackFull)
+
+	var err = NewInconsistentError("kek").(*implementation)
+	suite.Require().Empty(err.Location())
+	suite.Require().Empty(err.stack)
+
+	err = From(Catch(func() error { panic("kek") })).(*implementation)
+	suite.Require().Empty(err.Location())
+	suite.Require().Empty(err.stack)
+}
@@ -0,0 +1,152 @@
+// Command errsymbolize restores locations of stack traces recorded with errors.SetOfflineSymbolization.
+//
+// It reads stack traces, either StackTrace arrays or events written by errors.NewJSONLinesSink,
+// one per line from the standard input and writes them symbolized to the standard output:
+//
+//	errsymbolize -binary ./app < errors.jsonl
+//
+// Symbols of a binary can be exported before it is stripped and used instead of it:
+//
+//	errsymbolize -binary ./app -export app.sym
+//	errsymbolize -symbols app.sym < errors.jsonl
+//
+// Only ELF executables are supported. Inlined calls are not expanded,
+// their frames are named after the functions they are inlined into.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"flag"
+	"io"
+	"os"
+
+	"github.com/aerario/errors"
+)
+
+var ErrNoSymbols = errors.NewBadRequestFactory("either -binary or -symbols is required")
+
+// eventStackTrace is the member of errors.Event holding the stack trace.
+const eventStackTrace = "stack_trace"
+
+func main() {
+	errors.Main(func() error {
+		var (
+			binary  = flag.String("binary", "", "unstripped ELF binary the stack traces are recorded by")
+			symbols = flag.String("symbols", "", "symbol file exported by -export")
+			export  = flag.String("export", "", "export symbols of -binary to the file and exit")
+		)
+
+		flag.Parse()
+
+		return run(*binary, *symbols, *export, os.Stdin, os.Stdout)
+	})
+}
+
+func run(binary, symbols, export string, in io.Reader, out io.Writer) error {
+	var (
+		s   *Symbols
+		err error
+	)
+
+	switch {
+	case binary != "":
+		s, err = ReadBinary(binary)
+	case symbols != "":
+		s, err = ReadSymbols(symbols)
+	default:
+		return ErrNoSymbols.New()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if export != "" {
+		return WriteSymbols(export, s)
+	}
+
+	var symbolizer *Symbolizer
+	if symbolizer, err = NewSymbolizer(s); err != nil {
+		return err
+	}
+
+	return symbolize(symbolizer, in, out)
+}
+
+func symbolize(symbolizer *Symbolizer, in io.Reader, out io.Writer) error {
+	var scanner = bufio.NewScanner(in)
+	scanner.Buffer(nil, 16<<20)
+
+	for n := 1; scanner.Scan(); n++ {
+		var (
+			line   = bytes.TrimSpace(scanner.Bytes())
+			result any
+			err    error
+		)
+
+		switch {
+		case len(line) == 0:
+			continue
+		case line[0] == '[':
+			var frames []frame
+			if err = json.Unmarshal(line, &frames); err == nil {
+				err = symbolizer.Frames(frames)
+			}
+
+			result = frames
+		case line[0] == '{':
+			var event map[string]jsontext.Value
+			if err = json.Unmarshal(line, &event); err == nil {
+				err = symbolizeEvent(symbolizer, event)
+			}
+
+			result = event
+		default:
+			return ErrInvalidStackLine.New(n)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		var data []byte
+		if data, err = json.Marshal(result, json.Deterministic(true)); err != nil {
+			return err
+		}
+
+		if _, err = out.Write(append(data, '\n')); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// symbolizeEvent symbolizes the stack trace of an event, other members are kept as is.
+func symbolizeEvent(symbolizer *Symbolizer, event map[string]jsontext.Value) error {
+	var trace, ok = event[eventStackTrace]
+	if !ok {
+		return nil
+	}
+
+	var frames []frame
+	if err := json.Unmarshal(trace, &frames); err != nil {
+		return err
+	}
+
+	if err := symbolizer.Frames(frames); err != nil {
+		return err
+	}
+
+	var data, err = json.Marshal(frames)
+	if err != nil {
+		return err
+	}
+
+	event[eventStackTrace] = data
+
+	return nil
+}
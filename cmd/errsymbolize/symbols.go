@@ -0,0 +1,197 @@
+package main
+
+import (
+	"debug/elf"
+	"debug/gosym"
+	"encoding/json/v2"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aerario/errors"
+)
+
+var (
+	ErrNoLineTable      = errors.NewNotFoundFactory("no Go line table in %s")
+	ErrBuildIdMismatch  = errors.NewValidationFactory("stack trace is recorded by build %q, symbols are of build %q")
+	ErrInvalidPC        = errors.NewValidationFactory("invalid program counter %q")
+	ErrAnchorNotFound   = errors.NewNotFoundFactory("anchor function %s is not found")
+	ErrInvalidStackLine = errors.NewValidationFactory("stack trace line %d is neither an array of frames nor an event")
+)
+
+// Symbols is everything needed to symbolize program counters of a binary, it is exported to symbol files,
+// so that binaries themselves can be stripped.
+type Symbols struct {
+	BuildId   string `json:"build_id"`
+	TextStart uint64 `json:"text_start"`
+	LineTable []byte `json:"line_table"`
+}
+
+// ReadBinary reads symbols of an ELF executable, other formats are reported as errors.ErrNotELF.
+func ReadBinary(path string) (*Symbols, error) {
+	var (
+		out = &Symbols{}
+		err error
+	)
+
+	if out.BuildId, err = errors.ReadBuildId(path); err != nil {
+		return nil, err
+	}
+
+	var f *elf.File
+	if f, err = elf.Open(path); err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	var (
+		text  = f.Section(".text")
+		table = f.Section(".gopclntab")
+	)
+
+	if text == nil || table == nil {
+		return nil, ErrNoLineTable.New(path)
+	}
+
+	out.TextStart = text.Addr
+
+	if out.LineTable, err = table.Data(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// ReadSymbols reads a symbol file written by WriteSymbols.
+func ReadSymbols(path string) (*Symbols, error) {
+	var data, err = os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var out = &Symbols{}
+	if err = json.Unmarshal(data, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// WriteSymbols writes a symbol file.
+func WriteSymbols(path string, symbols *Symbols) error {
+	var data, err = json.Marshal(symbols)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Symbolizer restores locations of raw program counters.
+type Symbolizer struct {
+	symbols *Symbols
+	table   *gosym.Table
+	anchor  uint64 // address of errors.SymbolAnchor in the binary
+}
+
+func NewSymbolizer(symbols *Symbols) (*Symbolizer, error) {
+	var table, err = gosym.NewTable(nil, gosym.NewLineTable(symbols.LineTable, symbols.TextStart))
+	if err != nil {
+		return nil, err
+	}
+
+	var anchor = table.LookupFunc(errors.SymbolAnchor)
+	if anchor == nil {
+		return nil, ErrAnchorNotFound.New(errors.SymbolAnchor)
+	}
+
+	return &Symbolizer{symbols: symbols, table: table, anchor: anchor.Entry}, nil
+}
+
+// frame mirrors frames of errors.Stacker StackTrace.
+type frame struct {
+	Kind     string   `json:"kind"`
+	Labels   []string `json:"labels"`
+	Error    string   `json:"error"`
+	Location string   `json:"location,omitempty"`
+	Function string   `json:"function,omitempty"`
+	Source   []string `json:"source,omitempty"`
+	Stack    []string `json:"stack,omitempty"`
+	PC       string   `json:"pc,omitempty"`
+	PCs      []string `json:"pcs,omitempty"`
+	BuildId  string   `json:"build_id,omitempty"`
+	Module   string   `json:"module,omitempty"`
+	Anchor   string   `json:"anchor,omitempty"`
+}
+
+// Frames symbolizes frames of a stack trace, frames without program counters are left untouched.
+func (self *Symbolizer) Frames(frames []frame) error {
+	for i := range frames {
+		if err := self.frame(&frames[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (self *Symbolizer) frame(f *frame) error {
+	if f.PC == "" && len(f.PCs) == 0 {
+		return nil
+	}
+
+	if f.BuildId != self.symbols.BuildId {
+		return ErrBuildIdMismatch.New(f.BuildId, self.symbols.BuildId)
+	}
+
+	// position independent executables are loaded at an offset, the anchor reveals it
+	var anchor, err = parsePC(f.Anchor)
+	if err != nil {
+		return err
+	}
+
+	var offset = anchor - self.anchor
+
+	if f.PC != "" {
+		var pc uint64
+		if pc, err = parsePC(f.PC); err != nil {
+			return err
+		}
+
+		f.Function, f.Location = self.lookup(pc - offset)
+	}
+
+	for _, s := range f.PCs {
+		var pc uint64
+		if pc, err = parsePC(s); err != nil {
+			return err
+		}
+
+		var function, location = self.lookup(pc - offset)
+		f.Stack = append(f.Stack, function+" "+location)
+	}
+
+	return nil
+}
+
+// lookup symbolizes a return address, the call instruction precedes it.
+// Inlined calls are not expanded: the line table gives the position of the inlined code,
+// but the function is the one it is inlined into.
+func (self *Symbolizer) lookup(pc uint64) (function, location string) {
+	var file, line, fn = self.table.PCToLine(pc - 1)
+	if fn == nil {
+		return "?", "?"
+	}
+
+	return fn.Name, file + ":" + strconv.Itoa(line)
+}
+
+func parsePC(s string) (uint64, error) {
+	var pc, err = strconv.ParseUint(strings.TrimPrefix(s, "0x"), 16, 64)
+	if err != nil {
+		return 0, ErrInvalidPC.New(s).Wrap(err)
+	}
+
+	return pc, nil
+}
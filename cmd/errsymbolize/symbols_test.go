@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json/v2"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/aerario/errors"
+)
+
+type SymbolizeSuite struct {
+	suite.Suite
+
+	binary string
+}
+
+func TestSymbolizeSuite(t *testing.T) {
+	suite.Run(t, new(SymbolizeSuite))
+}
+
+func (suite *SymbolizeSuite) SetupSuite() {
+	var err error
+
+	suite.binary, err = os.Executable()
+	suite.Require().NoError(err)
+
+	errors.SetOfflineSymbolization(true)
+}
+
+func (suite *SymbolizeSuite) TearDownSuite() {
+	errors.SetOfflineSymbolization(false)
+}
+
+// recorded returns an error with a full stack and its stack trace with raw program counters.
+func (suite *SymbolizeSuite) recorded() (errors.Error, []byte) {
+	var err = errors.WithStackPolicy(errors.NewInconsistentFactory("kek"), errors.StackFull).New().
+		Wrap(errors.NewNotFoundError("lol"))
+
+	return err, err.(errors.Stacker).StackTrace()
+}
+
+func (suite *SymbolizeSuite) symbolize(binary, symbols string, in []byte) []frame {
+	var out bytes.Buffer
+	suite.Require().NoError(run(binary, symbols, "", bytes.NewReader(append(in, '\n')), &out))
+
+	var frames []frame
+	suite.Require().NoError(json.Unmarshal(out.Bytes(), &frames))
+
+	return frames
+}
+
+func (suite *SymbolizeSuite) TestRecorded() {
+	if !errors.CapturesStacks() {
+		suite.T().Skip("errors_nostack build captures no locations")
+	}
+
+	var _, trace = suite.recorded()
+
+	var frames []frame
+	suite.Require().NoError(json.Unmarshal(trace, &frames))
+	suite.Require().Len(frames, 2)
+
+	var id, err = errors.ReadBuildId(suite.binary)
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(id)
+
+	for _, f := range frames {
+		suite.Require().Empty(f.Location)
+		suite.Require().Empty(f.Function)
+		suite.Require().Empty(f.Stack)
+		suite.Require().NotEmpty(f.PC)
+		suite.Require().Equal(id, f.BuildId)
+		suite.Require().NotEmpty(f.Anchor)
+		suite.Require().Contains(f.Module, "github.com/aerario/errors@")
+	}
+
+	suite.Require().NotEmpty(frames[0].PCs)
+	suite.Require().Empty(frames[1].PCs)
+}
+
+func (suite *SymbolizeSuite) TestBinary() {
+	if !errors.CapturesStacks() {
+		suite.T().Skip("errors_nostack build captures no locations")
+	}
+
+	var err, trace = suite.recorded()
+
+	errors.SetOfflineSymbolization(false)
+	var location = err.(errors.Stacker).Location()
+	errors.SetOfflineSymbolization(true)
+
+	var frames = suite.symbolize(suite.binary, "", trace)
+	suite.Require().Len(frames, 2)
+	suite.Require().Equal("github.com/aerario/errors/cmd/errsymbolize.(*SymbolizeSuite).recorded", frames[0].Function)
+	suite.Require().True(strings.HasSuffix(frames[0].Location, location), frames[0].Location)
+	suite.Require().True(strings.HasPrefix(frames[0].Stack[0], frames[0].Function+" "), frames[0].Stack[0])
+	suite.Require().Contains(frames[0].Stack[1], "(*SymbolizeSuite).TestBinary")
+	suite.Require().Equal("kek", frames[0].Error)
+	suite.Require().Equal("Inconsistent", frames[0].Kind)
+	suite.Require().Contains(frames[1].Location, "symbols_test.go:")
+}
+
+func (suite *SymbolizeSuite) TestSymbolFile() {
+	var (
+		_, trace = suite.recorded()
+		file     = filepath.Join(suite.T().TempDir(), "errsymbolize.sym")
+	)
+
+	suite.Require().NoError(run(suite.binary, "", file, nil, nil))
+	suite.Require().Equal(suite.symbolize(suite.binary, "", trace), suite.symbolize("", file, trace))
+
+	suite.Require().True(errors.Is(run("", "", "", nil, nil), ErrNoSymbols))
+
+	var _, err = ReadBinary(file)
+	suite.Require().True(errors.Is(err, errors.ErrNotELF))
+}
+
+func (suite *SymbolizeSuite) TestEvent() {
+	if !errors.CapturesStacks() {
+		suite.T().Skip("errors_nostack build captures no locations")
+	}
+
+	var err, _ = suite.recorded()
+
+	var line, e = json.Marshal(errors.NewEvent(err))
+	suite.Require().NoError(e)
+
+	var out bytes.Buffer
+	suite.Require().NoError(run(suite.binary, "", "", bytes.NewReader(line), &out))
+
+	var symbolized struct {
+		Fingerprint string  `json:"fingerprint"`
+		StackTrace  []frame `json:"stack_trace"`
+	}
+
+	suite.Require().NoError(json.Unmarshal(out.Bytes(), &symbolized))
+	suite.Require().Len(symbolized.StackTrace, 2)
+	suite.Require().Contains(symbolized.StackTrace[0].Location, "symbols_test.go:")
+	suite.Require().Equal(errors.Fingerprint(err), symbolized.Fingerprint)
+}
+
+func (suite *SymbolizeSuite) TestLoadOffset() {
+	if !errors.CapturesStacks() {
+		suite.T().Skip("errors_nostack build captures no locations")
+	}
+
+	var _, trace = suite.recorded()
+
+	var expected = suite.symbolize(suite.binary, "", trace)
+
+	// program counters of position independent executables are shifted along with the anchor
+	var frames []frame
+	suite.Require().NoError(json.Unmarshal(trace, &frames))
+
+	var shift = func(s string) string {
+		var pc, err = parsePC(s)
+		suite.Require().NoError(err)
+
+		return "0x" + strconv.FormatUint(pc+0x10000, 16)
+	}
+
+	for i := range frames {
+		frames[i].PC, frames[i].Anchor = shift(frames[i].PC), shift(frames[i].Anchor)
+		for j := range frames[i].PCs {
+			frames[i].PCs[j] = shift(frames[i].PCs[j])
+		}
+	}
+
+	var shifted, err = json.Marshal(frames)
+	suite.Require().NoError(err)
+
+	var actual = suite.symbolize(suite.binary, "", shifted)
+	for i := range actual {
+		suite.Require().Equal(expected[i].Location, actual[i].Location)
+		suite.Require().Equal(expected[i].Stack, actual[i].Stack)
+	}
+}
+
+func (suite *SymbolizeSuite) TestMismatch() {
+	if !errors.CapturesStacks() {
+		suite.T().Skip("errors_nostack build captures no locations")
+	}
+
+	var _, trace = suite.recorded()
+
+	var frames []frame
+	suite.Require().NoError(json.Unmarshal(trace, &frames))
+	frames[0].BuildId = "kek"
+
+	var data, err = json.Marshal(frames)
+	suite.Require().NoError(err)
+
+	var symbolizer *Symbolizer
+	symbols, err := ReadBinary(suite.binary)
+	suite.Require().NoError(err)
+	symbolizer, err = NewSymbolizer(symbols)
+	suite.Require().NoError(err)
+
+	err = symbolize(symbolizer, bytes.NewReader(data), &bytes.Buffer{})
+	suite.Require().True(errors.Is(err, ErrBuildIdMismatch))
+
+	err = symbolize(symbolizer, strings.NewReader("kek"), &bytes.Buffer{})
+	suite.Require().True(errors.Is(err, ErrInvalidStackLine))
+}
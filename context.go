@@ -0,0 +1,73 @@
+package errors
+
+import (
+	"context"
+	"maps"
+
+	"github.com/aerario/errors/tracing"
+)
+
+const (
+	DetailRequestId = "request_id"
+	DetailTenant    = "tenant"
+	DetailUserId    = "user_id"
+)
+
+type contextDetailsKey struct{}
+
+var contextExtractors hookList[func(context.Context) map[string]string]
+
+// WithContextDetails returns a context carrying details merged with the ones the parent context carries.
+// Errors made by NewCtx and Factory.NewCtx get these details.
+func WithContextDetails(ctx context.Context, details map[string]string) context.Context {
+	var merged = maps.Clone(contextDetails(ctx))
+	if merged == nil {
+		merged = make(map[string]string, len(details))
+	}
+
+	maps.Copy(merged, details)
+
+	return context.WithValue(ctx, contextDetailsKey{}, merged)
+}
+
+func WithRequestId(ctx context.Context, id string) context.Context {
+	return WithContextDetails(ctx, map[string]string{DetailRequestId: id})
+}
+
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return WithContextDetails(ctx, map[string]string{DetailTenant: tenant})
+}
+
+func WithUserId(ctx context.Context, id string) context.Context {
+	return WithContextDetails(ctx, map[string]string{DetailUserId: id})
+}
+
+// RegisterContextExtractor registers a function pulling arbitrary details out of a context.
+// The returned function removes the extractor.
+func RegisterContextExtractor(extractor func(context.Context) map[string]string) (remove func()) {
+	return contextExtractors.add(extractor)
+}
+
+// ContextDetails returns details of the context: the ones set by WithContextDetails,
+// trace ids known to the tracing package and the ones pulled by registered extractors.
+func ContextDetails(ctx context.Context) map[string]string {
+	var out = make(map[string]string)
+
+	if traceId, spanId, ok := tracing.IDsFromContext(ctx); ok {
+		out[DetailTraceId] = traceId
+		out[DetailSpanId] = spanId
+	}
+
+	for _, h := range contextExtractors.load() {
+		maps.Copy(out, h.fn(ctx))
+	}
+
+	maps.Copy(out, contextDetails(ctx))
+
+	return out
+}
+
+func contextDetails(ctx context.Context) map[string]string {
+	var details, _ = ctx.Value(contextDetailsKey{}).(map[string]string)
+	return details
+}
@@ -0,0 +1,56 @@
+package errors
+
+import (
+	"context"
+
+	"github.com/aerario/errors/tracing"
+)
+
+type tenantKey struct{}
+
+func (suite *ErrorsSuite) TestContextDetails() {
+	var ctx = WithRequestId(context.Background(), "req")
+	ctx = WithUserId(WithTenant(ctx, "acme"), "42")
+	ctx = WithContextDetails(ctx, map[string]string{"shard": "7"})
+	ctx = tracing.ContextWithIDs(ctx, "trace", "span")
+
+	var expected = map[string]string{
+		DetailRequestId: "req",
+		DetailTenant:    "acme",
+		DetailUserId:    "42",
+		DetailTraceId:   "trace",
+		DetailSpanId:    "span",
+		"shard":         "7",
+	}
+
+	suite.Require().Equal(expected, ContextDetails(ctx))
+	suite.Require().Empty(ContextDetails(context.Background()))
+
+	var err = NewCtx(ctx, ErrKindNotFound, "user %d not found", 42)
+	suite.Require().Equal(expected, err.Details())
+	suite.Require().Equal(ErrKindNotFound, KindOf(err))
+	suite.requireLocation(err, "context_test.go")
+
+	err = NewNotFoundFactory("user %d not found").NewCtx(ctx, 42).WithDetails(map[string]string{"shard": "8"})
+	expected["shard"] = "8"
+	suite.Require().Equal(expected, err.Details())
+	suite.requireLocation(err, "context_test.go")
+}
+
+func (suite *ErrorsSuite) TestContextExtractor() {
+	var remove = RegisterContextExtractor(func(ctx context.Context) map[string]string {
+		if tenant, ok := ctx.Value(tenantKey{}).(string); ok {
+			return map[string]string{DetailTenant: tenant}
+		}
+
+		return nil
+	})
+	defer remove()
+
+	var ctx = context.WithValue(context.Background(), tenantKey{}, "acme")
+	suite.Require().Equal(map[string]string{DetailTenant: "acme"}, NewCtx(ctx, ErrKindTimeout, "timed out").Details())
+
+	// explicitly set details win
+	ctx = WithTenant(ctx, "umbrella")
+	suite.Require().Equal(map[string]string{DetailTenant: "umbrella"}, NewCtx(ctx, ErrKindTimeout, "timed out").Details())
+}
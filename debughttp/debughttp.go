@@ -0,0 +1,115 @@
+// Package debughttp serves recently reported errors over HTTP, similarly to net/http/pprof:
+//
+//	var recent = errors.NewRecentErrors(256)
+//	reporter.AddSink(recent)
+//	debughttp.Register(http.DefaultServeMux, recent)
+//
+// The page is served at /debug/errors, JSON is served at /debug/errors.json.
+// Both accept kind and label query parameters to filter errors.
+package debughttp
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"html/template"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/aerario/errors"
+)
+
+const (
+	PagePath = "/debug/errors"
+	JSONPath = "/debug/errors.json"
+)
+
+// Register serves the store on mux at PagePath and JSONPath.
+func Register(mux *http.ServeMux, store *errors.RecentErrors) {
+	var h = Handler(store)
+
+	mux.Handle(PagePath, h)
+	mux.Handle(JSONPath, h)
+}
+
+// Handler serves the store as JSON if the path ends with .json or format=json is requested, as HTML otherwise.
+func Handler(store *errors.RecentErrors) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			query  = r.URL.Query()
+			filter = errors.RecentFilter{
+				Kind:  query.Get("kind"),
+				Label: errors.Label(query.Get("label")),
+			}
+			groups = store.Snapshot(filter)
+		)
+
+		if strings.HasSuffix(r.URL.Path, ".json") || query.Get("format") == "json" {
+			w.Header().Set("Content-Type", "application/json")
+
+			if err := json.MarshalWrite(w, groups); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+		if err := page.Execute(w, struct {
+			Filter errors.RecentFilter
+			Groups []errors.RecentError
+		}{
+			Filter: filter,
+			Groups: groups,
+		}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+var page = template.Must(template.New("errors").Funcs(template.FuncMap{
+	"indent": func(v jsontext.Value) string {
+		var out = slices.Clone(v)
+		if err := out.Indent(); err != nil {
+			return string(v)
+		}
+
+		return string(out)
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>/debug/errors</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+pre { margin: 0; white-space: pre-wrap; font-size: 12px; }
+</style>
+</head>
+<body>
+<h1>/debug/errors</h1>
+<form>
+kind <input name="kind" value="{{ .Filter.Kind }}">
+label <input name="label" value="{{ .Filter.Label }}">
+<input type="submit" value="filter">
+</form>
+<p>{{ len .Groups }} error groups</p>
+<table>
+<tr><th>count</th><th>kind</th><th>code</th><th>labels</th><th>first seen</th><th>last seen</th><th>message</th></tr>
+{{ range .Groups }}
+<tr>
+<td>{{ .Count }}</td>
+<td>{{ .Kind }}</td>
+<td>{{ .Code }}</td>
+<td>{{ range .Labels }}{{ . }} {{ end }}</td>
+<td>{{ .FirstSeen.Format "2006-01-02 15:04:05" }}</td>
+<td>{{ .LastSeen.Format "2006-01-02 15:04:05" }}</td>
+<td>{{ .Message }}<details><summary>{{ .Fingerprint }}</summary><pre>{{ indent .StackTrace }}</pre></details></td>
+</tr>
+{{ end }}
+</table>
+</body>
+</html>
+`))
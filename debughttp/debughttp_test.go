@@ -0,0 +1,71 @@
+package debughttp
+
+import (
+	"encoding/json/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/aerario/errors"
+)
+
+type DebugHTTPSuite struct {
+	suite.Suite
+	mux *http.ServeMux
+}
+
+func TestDebugHTTPSuite(t *testing.T) {
+	suite.Run(t, new(DebugHTTPSuite))
+}
+
+func (suite *DebugHTTPSuite) SetupTest() {
+	var store = errors.NewRecentErrors(10)
+
+	suite.Require().NoError(store.Write(errors.NewEvent(errors.NewTimeoutError("timed out"))))
+	suite.Require().NoError(store.Write(errors.NewEvent(errors.NewNotFoundFactory("<b>not found</b>").New())))
+
+	suite.mux = http.NewServeMux()
+	Register(suite.mux, store)
+}
+
+func (suite *DebugHTTPSuite) get(target string) *httptest.ResponseRecorder {
+	var w = httptest.NewRecorder()
+	suite.mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+
+	return w
+}
+
+func (suite *DebugHTTPSuite) TestJSON() {
+	var tests = []struct {
+		target string
+		want   int
+	}{
+		{target: JSONPath, want: 2},
+		{target: PagePath + "?format=json", want: 2},
+		{target: JSONPath + "?kind=Timeout", want: 1},
+		{target: JSONPath + "?label=user-friendly", want: 1},
+		{target: JSONPath + "?kind=Validation", want: 0},
+	}
+
+	for _, t := range tests {
+		suite.Run(t.target, func() {
+			var w = suite.get(t.target)
+			suite.Require().Equal(http.StatusOK, w.Code)
+			suite.Require().Equal("application/json", w.Header().Get("Content-Type"))
+
+			var groups []errors.RecentError
+			suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &groups))
+			suite.Require().Len(groups, t.want)
+		})
+	}
+}
+
+func (suite *DebugHTTPSuite) TestPage() {
+	var w = suite.get(PagePath + "?kind=NotFound")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Require().Contains(w.Body.String(), "1 error groups")
+	suite.Require().Contains(w.Body.String(), "&lt;b&gt;not found&lt;/b&gt;")
+	suite.Require().Contains(w.Body.String(), `value="NotFound"`)
+}
@@ -0,0 +1,21 @@
+// Package errors provides errors of a fixed set of kinds with labels, details and locations.
+//
+// # Performance
+//
+// Locations are captured as program counters and symbolized only when printed out,
+// symbolized locations, template ids and metric counters are cached per call site.
+// Messages returned by Error are memoized until the error or any of its causes is mutated.
+// What is captured is defined by StackPolicy set globally, per kind or per factory,
+// errors_nostack build tag turns capturing off completely. SetOfflineSymbolization leaves program counters
+// of stack traces unsymbolized, cmd/errsymbolize restores them with the matching binary.
+//
+// Allocation budgets, kept by TestAllocations, see bench_test.go for comparison with errors.New and fmt.Errorf:
+//
+//   - New without arguments: 1
+//   - New with arguments: 2, the formatted message is the second one
+//   - Factory.New with arguments: 3, the arguments slice is the third one
+//   - Wrap, Error of a memoized message, Kind.String and ParseKind: 0
+//   - errors of NewSentinel and Factory.Static: 0, they are allocated once
+//
+// Once any Helper is marked, locations are found by walking the stack, it takes 2 more allocations per error.
+package errors
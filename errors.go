@@ -1,10 +1,13 @@
 package errors
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"maps"
+	"slices"
 	"strings"
+	"sync/atomic"
 )
 
 var DefaultUserFriendlyError = "something went wrong"
@@ -23,12 +26,35 @@ type Error interface {
 
 func New(kind Kind, message string, args ...interface{}) Error {
 	var err = &implementation{
-		id:      errorId(message),
 		kind:    kind,
-		message: fmt.Sprintf(message, args...),
+		message: format(message, args),
 	}
 
-	err.setLocation(2)
+	err.constructFrom(nil, message, 1)
+
+	return err
+}
+
+// NewSkip returns a new error which location is skip frames above the caller, see also Helper.
+func NewSkip(skip int, kind Kind, message string, args ...interface{}) Error {
+	var err = &implementation{
+		kind:    kind,
+		message: format(message, args),
+	}
+
+	err.constructFrom(nil, message, skip+1)
+
+	return err
+}
+
+// NewCtx returns a new error enriched with details found in the context, see ContextDetails.
+func NewCtx(ctx context.Context, kind Kind, message string, args ...interface{}) Error {
+	var err = &implementation{
+		kind:    kind,
+		message: format(message, args),
+	}
+
+	err.constructFrom(ctx, message, 1)
 
 	return err
 }
@@ -39,20 +65,84 @@ type implementation struct {
 	// because of the arguments, so we need to remember its template.
 	id       uint32
 	kind     Kind
+	code     string
 	labels   LabelList
 	message  string
 	previous error
 	details  map[string]string
 	location location
+	stack    stack
+	memo     atomic.Pointer[errorMemo]
+	version  uint32 // incremented by mutators changing Error, see chainVersions
+	static   bool   // shared immutable error, see NewSentinel
+}
+
+// errorMemo is a memoized message of Error.
+type errorMemo struct {
+	message  string
+	versions []uint32 // versions of the chain the message is made of
+}
+
+// construct finishes a new error: merges context details, captures its location according to the policy
+// unless capture hooks veto it and calls hooks.
+func (self *implementation) construct(ctx context.Context, policy StackPolicy, callDepth int) {
+	self.withContext(ctx)
+
+	if captures(self) {
+		self.capture(policy, callDepth+1)
+	}
+
+	created(self)
+}
+
+// constructFrom is construct for errors made without a factory, ids of their templates are cached per call site.
+func (self *implementation) constructFrom(ctx context.Context, template string, callDepth int) {
+	self.withContext(ctx)
+
+	if captures(self) {
+		self.capture(StackDefault, callDepth+1)
+	}
+
+	self.id = templateId(self.location.pc, template)
+
+	created(self)
+}
+
+func (self *implementation) withContext(ctx context.Context) {
+	if ctx != nil {
+		self.details = ContextDetails(ctx)
+	}
+}
+
+// format skips fmt for templates without arguments and verbs.
+func format(template string, args []interface{}) string {
+	if len(args) == 0 && strings.IndexByte(template, '%') < 0 {
+		return template
+	}
+
+	return fmt.Sprintf(template, args...)
 }
 
 func (self *implementation) Annotate(message string, args ...interface{}) Error {
-	self.message += ": " + fmt.Sprintf(message, args...)
+	if self.static {
+		return self.derive(1).Annotate(message, args...)
+	}
+
+	self.message += ": " + format(message, args)
+	self.version++
+
 	return self
 }
 
 func (self *implementation) Wrap(err error) Error {
+	if self.static {
+		return self.derive(1).Wrap(err)
+	}
+
 	self.previous = err
+	self.version++
+	wrapped(self, err)
+
 	return self
 }
 
@@ -65,24 +155,53 @@ func (self *implementation) Labels() LabelList {
 }
 
 func (self *implementation) WithLabels(in ...Label) Error {
+	if self.static {
+		return self.derive(1).WithLabels(in...)
+	}
+
 	self.labels = self.labels.Add(in...)
+	self.version++
+
 	return self
 }
 
 func (self *implementation) Details() map[string]string {
 	var details = make(map[string]string)
 
-	var prev Error
-	if As(self.previous, &prev) {
-		details = prev.Details()
-	}
-
+	causeDetails(self.previous, details)
 	maps.Copy(details, self.details)
 
 	return details
 }
 
+// causeDetails copies details of the first Error of the chain, or of each of joined causes, into the map.
+func causeDetails(err error, into map[string]string) {
+	for err != nil {
+		switch t := err.(type) {
+		case Error:
+			maps.Copy(into, t.Details())
+			return
+		case interface{ Unwrap() []error }:
+			for _, cause := range t.Unwrap() {
+				causeDetails(cause, into)
+			}
+
+			return
+		}
+
+		err = errors.Unwrap(err)
+	}
+}
+
 func (self *implementation) WithDetails(in map[string]string) Error {
+	if self.static {
+		return self.derive(1).WithDetails(in)
+	}
+
+	if self.details == nil {
+		self.details = make(map[string]string, len(in))
+	}
+
 	maps.Copy(self.details, in)
 
 	return self
@@ -109,35 +228,88 @@ func (self *implementation) String() string {
 	return self.message
 }
 
+// ErrorCode returns the error code if there is one, otherwise the kind name.
 func (self *implementation) ErrorCode() string {
+	if self.code != "" {
+		return self.code
+	}
+
 	return kindName(self.kind)
 }
 
 // Error concatenates and prints out all underlying user-friendly errors
 func (self *implementation) Error() string {
 	var (
-		err = error(self)
-		out []string
+		buf      [16]uint32
+		versions = chainVersions(buf[:0], self)
 	)
 
+	if m := self.memo.Load(); m != nil && slices.Equal(m.versions, versions) {
+		return m.message
+	}
+
+	var out = userFriendly(self)
+
+	// the default message is not memoized, it may be changed at any time
+	if len(out) == 0 {
+		return DefaultUserFriendlyError
+	}
+
+	var message = strings.Join(out, ": ")
+	self.memo.Store(&errorMemo{message: message, versions: slices.Clone(versions)})
+
+	return message
+}
+
+// chainVersions appends versions of the errors userFriendly walks through to out.
+// Mutators increment versions and only Wrap changes the chain, so equal versions mean the same messages.
+func chainVersions(out []uint32, err error) []uint32 {
 	for err != nil {
 		switch t := err.(type) {
 		case *implementation:
-			if t.labels.Has(LabelUserFriendly) {
-				out = append(out, t.String())
+			out = append(out, t.version)
+		case *boundary:
+			return out
+		case interface{ Unwrap() []error }:
+			for _, cause := range t.Unwrap() {
+				out = chainVersions(out, cause)
 			}
+
+			return out
 		}
 
 		err = errors.Unwrap(err)
 	}
 
-	if len(out) == 0 {
-		return DefaultUserFriendlyError
+	return out
+}
+
+// userFriendly collects messages of user-friendly errors of the chain.
+func userFriendly(err error) []string {
+	var out []string
+
+	for err != nil {
+		switch t := err.(type) {
+		case *implementation:
+			if t.labels.Has(LabelUserFriendly) {
+				out = append(out, t.String())
+			}
+		case *boundary:
+			return out
+		case interface{ Unwrap() []error }:
+			if causes := joinMessages(t.Unwrap(), userFriendly); causes != "" {
+				out = append(out, causes)
+			}
+
+			return out
+		}
+
+		err = errors.Unwrap(err)
 	}
 
-	return strings.Join(out, ": ")
+	return out
 }
 
-func (self implementation) MarshalJSON() ([]byte, error) {
+func (self *implementation) MarshalJSON() ([]byte, error) {
 	return fmt.Appendf([]byte{}, "%q", self.Error()), nil
 }
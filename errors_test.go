@@ -18,6 +18,32 @@ func TestErrorsSuite(t *testing.T) {
 	suite.Run(t, new(ErrorsSuite))
 }
 
+// requireStacks skips tests of locations and stacks, errors_nostack builds capture none.
+func (suite *ErrorsSuite) requireStacks() {
+	if !stackCapture {
+		suite.T().Skip("errors_nostack build captures no locations")
+	}
+}
+
+// requireLocation checks that the error is located in the file, errors_nostack builds capture no locations.
+func (suite *ErrorsSuite) requireLocation(err error, file string) {
+	if !stackCapture {
+		suite.Require().Empty(err.(Stacker).Location())
+		return
+	}
+
+	suite.Require().Contains(err.(Stacker).Location(), file)
+}
+
+// testPackage is the package of errors created by the tests, there is none for errors with no location.
+func testPackage() string {
+	if !stackCapture {
+		return ""
+	}
+
+	return "github.com/aerario/errors"
+}
+
 func (suite *ErrorsSuite) TestFrom() {
 	var implErr = NewAlreadyExistsError("kek bek")
 	var tests = []struct {
@@ -140,6 +166,27 @@ func (suite *ErrorsSuite) TestIn() {
 	}
 }
 
+func (suite *ErrorsSuite) TestIsFactoryCreatesNothing() {
+	var (
+		fac = NewNotFoundFactory("user %d not found")
+		err = fac.New(1)
+	)
+
+	ResetMetrics()
+
+	var created int
+	var remove = OnCreate(func(Error) { created++ })
+	defer remove()
+
+	for range 5 {
+		suite.Require().True(Is(err, fac))
+		suite.Require().False(Is(err, NewTimeoutFactory("user %d not found")))
+	}
+
+	suite.Require().Zero(created)
+	suite.Require().Empty(Metrics())
+}
+
 func (suite *ErrorsSuite) TestHumanFriendly() {
 	var (
 		first  = NewAuthenticationFactory("invalid login/password").New()
@@ -304,3 +351,56 @@ func (suite *ErrorsSuite) TestDetails() {
 		"field1": "test err",
 	})
 }
+
+func (suite *ErrorsSuite) TestErrorMemo() {
+	var (
+		inner = NewInfrastructureError("db is down")
+		outer = NewNotFoundFactory("user not found").New().Wrap(inner)
+	)
+
+	suite.Require().Equal("user not found", outer.Error())
+	suite.Require().Equal("user not found", outer.Error())
+
+	// mutation of a cause invalidates the message
+	inner.WithLabels(LabelUserFriendly)
+	suite.Require().Equal("user not found: db is down", outer.Error())
+
+	inner.Annotate("kek")
+	suite.Require().Equal("user not found: db is down: kek", outer.Error())
+
+	// mutations of unrelated errors keep the message
+	var memo = outer.(*implementation).memo.Load()
+	NewNotFoundError("kek").Annotate("bek").WithLabels(LabelUserFriendly)
+	suite.Require().Equal("user not found: db is down: kek", outer.Error())
+	suite.Require().Same(memo, outer.(*implementation).memo.Load())
+
+	// a new cause of the same version is not mistaken for the old one
+	outer.Wrap(NewInfrastructureFactory("cache is down").New())
+	suite.Require().Equal("user not found: cache is down", outer.Error())
+
+	// the default message is never memoized
+	var prev = DefaultUserFriendlyError
+	defer func() { DefaultUserFriendlyError = prev }()
+
+	var err = NewInfrastructureError("db is down")
+	suite.Require().Equal(prev, err.Error())
+
+	DefaultUserFriendlyError = "kek"
+	suite.Require().Equal("kek", err.Error())
+}
+
+func (suite *ErrorsSuite) TestTemplateId() {
+	var ids []uint32
+
+	for _, template := range []string{"kek %d", "lol %d", "kek %d"} {
+		ids = append(ids, New(ErrKindGeneral, template, 1).(*implementation).id)
+	}
+
+	suite.Require().Equal([]uint32{errorId("kek %d"), errorId("lol %d"), errorId("kek %d")}, ids)
+	suite.Require().Equal(NewFactory(ErrKindGeneral, "kek %d").(*factory).id, ids[0])
+	suite.Require().True(Is(New(ErrKindGeneral, "kek %d", 2), NewFactory(ErrKindGeneral, "kek %d")))
+
+	// hash/fnv.New32 compatibility
+	suite.Require().Equal(uint32(0x811c9dc5), errorId(""))
+	suite.Require().Equal(uint32(0x050c5d7e), errorId("a"))
+}
@@ -0,0 +1,122 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"sync/atomic"
+)
+
+// Exit codes defined by sysexits.h.
+const (
+	ExitUsage       = 64
+	ExitDataErr     = 65
+	ExitNoInput     = 66
+	ExitUnavailable = 69
+	ExitSoftware    = 70
+	ExitCantCreate  = 73
+	ExitIOErr       = 74
+	ExitTempFail    = 75
+	ExitNoPerm      = 77
+)
+
+// DebugEnv is an environment variable which enables printing of raw errors and stack traces on Exit.
+const DebugEnv = "ERRORS_DEBUG"
+
+// ExitCodes maps kinds to process exit codes, unknown kinds exit with ExitSoftware.
+var ExitCodes = map[Kind]int{
+	ErrKindGeneral:        ExitSoftware,
+	ErrKindAuthentication: ExitNoPerm,
+	ErrKindAuthorization:  ExitNoPerm,
+	ErrKindBadRequest:     ExitUsage,
+	ErrKindValidation:     ExitUsage,
+	ErrKindNotFound:       ExitNoInput,
+	ErrKindAlreadyExists:  ExitCantCreate,
+	ErrKindLimitExceeded:  ExitTempFail,
+	ErrKindInconsistent:   ExitDataErr,
+	ErrKindPersistence:    ExitIOErr,
+	ErrKindInfrastructure: ExitUnavailable,
+	ErrKindThirdParties:   ExitUnavailable,
+	ErrKindTimeout:        ExitTempFail,
+}
+
+var (
+	debugEnabled atomic.Bool
+
+	// replaced in tests
+	exit             = os.Exit
+	stderr io.Writer = os.Stderr
+)
+
+// SetDebug enables printing of raw errors and stack traces on Exit, e.g. by a command line flag.
+func SetDebug(enabled bool) {
+	debugEnabled.Store(enabled)
+}
+
+// ExitCode returns a process exit code for the error, 0 for nil.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	if code, ok := ExitCodes[KindOf(err)]; ok {
+		return code
+	}
+
+	return ExitSoftware
+}
+
+// Exit prints the error to stderr and terminates the process with ExitCode.
+// Only the user-friendly message is printed unless debug is enabled by SetDebug or DebugEnv,
+// errors with no user-friendly message, such as panics and errors of other packages, are printed raw.
+func Exit(err error) {
+	if err != nil {
+		printError(stderr, err)
+	}
+
+	exit(ExitCode(err))
+}
+
+// Main runs fn and exits with its error, panics are converted into errors:
+//
+//	func main() {
+//		errors.Main(run)
+//	}
+func Main(fn func() error) {
+	Exit(Catch(fn))
+}
+
+func printError(w io.Writer, err error) {
+	var e = From(err)
+
+	if !isDebug() {
+		var message = e.Error()
+
+		// the default message tells nothing to the one running the command
+		if len(userFriendly(e)) == 0 {
+			message = Raw(e).Error()
+		}
+
+		fmt.Fprintf(w, "error: %s\n", message)
+		return
+	}
+
+	fmt.Fprintf(w, "error: %s\n", Raw(e).Error())
+
+	var trace = slices.Clone(e.(Stacker).StackTrace())
+	if trace.Indent() == nil {
+		fmt.Fprintf(w, "%s\n", trace)
+	}
+}
+
+func isDebug() bool {
+	if debugEnabled.Load() {
+		return true
+	}
+
+	var enabled, _ = strconv.ParseBool(os.Getenv(DebugEnv))
+
+	return enabled
+}
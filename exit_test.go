@@ -0,0 +1,91 @@
+package errors
+
+import (
+	"bytes"
+	"errors"
+)
+
+func (suite *ErrorsSuite) withExit(fn func()) (code int, output string) {
+	var (
+		buf                  bytes.Buffer
+		prevExit, prevStderr = exit, stderr
+	)
+
+	defer func() { exit, stderr = prevExit, prevStderr }()
+
+	code = -1
+	exit = func(c int) { code = c }
+	stderr = &buf
+
+	fn()
+
+	return code, buf.String()
+}
+
+func (suite *ErrorsSuite) TestExitCode() {
+	var tests = []struct {
+		err  error
+		want int
+	}{
+		{err: nil, want: 0},
+		{err: errors.New("kek"), want: ExitSoftware},
+		{err: NewValidationError("kek"), want: ExitUsage},
+		{err: NewBadRequestError("kek"), want: ExitUsage},
+		{err: NewNotFoundError("kek"), want: ExitNoInput},
+		{err: NewAuthorizationError("kek"), want: ExitNoPerm},
+		{err: NewInfrastructureError("kek"), want: ExitUnavailable},
+		{err: NewTimeoutError("kek"), want: ExitTempFail},
+		{err: New(Kind(100500), "kek"), want: ExitSoftware},
+	}
+
+	for _, t := range tests {
+		suite.Require().Equal(t.want, ExitCode(t.err))
+	}
+
+	ExitCodes[ErrKindNotFound] = 1
+	defer func() { ExitCodes[ErrKindNotFound] = ExitNoInput }()
+
+	suite.Require().Equal(1, ExitCode(NewNotFoundError("kek")))
+}
+
+func (suite *ErrorsSuite) TestExit() {
+	var err = NewNotFoundFactory("file %s not found").New("kek.txt").Wrap(errors.New("open kek.txt: no such file"))
+
+	var code, output = suite.withExit(func() { Exit(err) })
+	suite.Require().Equal(ExitNoInput, code)
+	suite.Require().Equal("error: file kek.txt not found\n", output)
+
+	code, output = suite.withExit(func() { Exit(nil) })
+	suite.Require().Zero(code)
+	suite.Require().Empty(output)
+}
+
+func (suite *ErrorsSuite) TestExitDebug() {
+	var err = NewNotFoundFactory("file %s not found").New("kek.txt").Wrap(errors.New("open kek.txt: no such file"))
+
+	suite.T().Setenv(DebugEnv, "1")
+
+	var code, output = suite.withExit(func() { Exit(err) })
+	suite.Require().Equal(ExitNoInput, code)
+	suite.Require().Contains(output, "error: file kek.txt not found: open kek.txt: no such file\n")
+	suite.Require().Contains(output, `"kind": "NotFound"`)
+
+	suite.T().Setenv(DebugEnv, "")
+	SetDebug(true)
+	defer SetDebug(false)
+
+	_, output = suite.withExit(func() { Exit(err) })
+	suite.Require().Contains(output, `"kind": "NotFound"`)
+}
+
+func (suite *ErrorsSuite) TestMain() {
+	var code, output = suite.withExit(func() { Main(func() error { panic("kek") }) })
+	suite.Require().Equal(ExitSoftware, code)
+	suite.Require().Equal("error: panic: kek\n", output)
+
+	_, output = suite.withExit(func() { Main(func() error { return errors.New("open kek.txt: no such file") }) })
+	suite.Require().Equal("error: open kek.txt: no such file\n", output)
+
+	code, _ = suite.withExit(func() { Main(func() error { return nil }) })
+	suite.Require().Zero(code)
+}
@@ -1,21 +1,29 @@
 package errors
 
 import (
-	"fmt"
+	"context"
+	"slices"
 )
 
 type Factory interface {
 	WithLabels(...Label) Factory
+	Static() Error
 	New(args ...interface{}) Error
+	NewSkip(skip int, args ...interface{}) Error
+	NewCtx(ctx context.Context, args ...interface{}) Error
 }
 
 func NewFactory(kind Kind, template string) Factory {
-	return &factory{
+	var f = &factory{
 		id:       errorId(template),
 		kind:     kind,
 		template: template,
 		labels:   LabelList{LabelUserFriendly}, // factory-made errors are always user-friendly
 	}
+
+	f.static = f.sentinel()
+
+	return f
 }
 
 type factory struct {
@@ -25,19 +33,46 @@ type factory struct {
 	id       uint32
 	kind     Kind
 	template string
+	code     string
 	labels   LabelList
+	stack    StackPolicy
+	static   *implementation // built eagerly, so that Static always returns the same error
 }
 
 func (f factory) New(args ...interface{}) Error {
-	var err = &implementation{
+	var err = f.make(args)
+
+	err.construct(nil, f.stack, 1)
+
+	return err
+}
+
+// NewSkip returns a new error which location is skip frames above the caller, see also Helper.
+func (f factory) NewSkip(skip int, args ...interface{}) Error {
+	var err = f.make(args)
+
+	err.construct(nil, f.stack, skip+1)
+
+	return err
+}
+
+// NewCtx returns a new error enriched with details found in the context, see ContextDetails.
+func (f factory) NewCtx(ctx context.Context, args ...interface{}) Error {
+	var err = f.make(args)
+
+	err.construct(ctx, f.stack, 1)
+
+	return err
+}
+
+func (f factory) make(args []interface{}) *implementation {
+	return &implementation{
 		id:      f.id,
 		kind:    f.kind,
-		message: fmt.Sprintf(f.template, args...),
+		code:    f.code,
+		labels:  slices.Clip(f.labels), // appends to labels of an error reallocate them
+		message: format(f.template, args),
 	}
-
-	err.setLocation(1)
-
-	return err.WithLabels(f.labels...)
 }
 
 func (f factory) WithLabels(labels ...Label) Factory {
@@ -46,6 +81,91 @@ func (f factory) WithLabels(labels ...Label) Factory {
 	newFactory.labels = make(LabelList, 0, len(f.labels)+len(labels))
 	newFactory.labels = append(newFactory.labels, f.labels...)
 	newFactory.labels = append(newFactory.labels, labels...)
+	newFactory.static = newFactory.sentinel()
+
+	return &newFactory
+}
+
+// WithCode returns a factory which errors carry an application-specific code, see Code.
+// Codes are optional for implementations of Factory, factories which do not support them are returned as is.
+func WithCode(f Factory, code string) Factory {
+	if t, ok := f.(interface{ WithCode(code string) Factory }); ok {
+		return t.WithCode(code)
+	}
+
+	return f
+}
+
+func (f factory) WithCode(code string) Factory {
+	var newFactory = f
+
+	newFactory.code = code
+	newFactory.static = newFactory.sentinel()
 
 	return &newFactory
 }
+
+// WithStackPolicy returns a factory which errors are captured according to the policy regardless of their kind,
+// factories which do not support stack policies are returned as is, see WithCode.
+func WithStackPolicy(f Factory, policy StackPolicy) Factory {
+	if t, ok := f.(interface{ WithStackPolicy(StackPolicy) Factory }); ok {
+		return t.WithStackPolicy(policy)
+	}
+
+	return f
+}
+
+func (f factory) WithStackPolicy(policy StackPolicy) Factory {
+	var newFactory = f
+
+	newFactory.stack = policy
+
+	return &newFactory
+}
+
+// Static returns a shared immutable error of the factory without location, its message is the template
+// formatted without arguments. It is meant for hot paths, mutators of the error return its copies, see NewSentinel.
+// Templates with verbs are kept as is and are not fit for users, so errors of such factories are not user-friendly.
+func (f factory) Static() Error {
+	return f.static
+}
+
+func (f factory) sentinel() *implementation {
+	var (
+		labels  = slices.Clip(f.labels)
+		message = f.template
+	)
+
+	if hasVerbs(f.template) {
+		labels = removeLabel(labels, LabelUserFriendly)
+	} else {
+		message = format(f.template, nil)
+	}
+
+	return &implementation{
+		id:      f.id,
+		kind:    f.kind,
+		code:    f.code,
+		labels:  labels,
+		message: message,
+		static:  true,
+	}
+}
+
+// hasVerbs reports whether the template has formatting verbs, escaped percent signs are not verbs.
+func hasVerbs(template string) bool {
+	for i := 0; i < len(template); i++ {
+		if template[i] != '%' {
+			continue
+		}
+
+		if i+1 < len(template) && template[i+1] == '%' {
+			i++
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
@@ -10,3 +10,13 @@ func (suite *ErrorsSuite) TestWithLabels() {
 	suite.Require().NotNil(typedErr)
 	suite.Require().ElementsMatch(typedErr.labels, []Label{LabelUserFriendly, "TagFallbackable", "TagProcessing"})
 }
+
+// foreignFactory is an implementation of Factory by another package.
+type foreignFactory struct {
+	Factory
+}
+
+func (suite *ErrorsSuite) TestWithCode() {
+	suite.Require().Equal("USER-404", Code(WithCode(NewNotFoundFactory("kek"), "USER-404").New()))
+	suite.Require().Equal(foreignFactory{}, WithCode(foreignFactory{}, "USER-404"))
+}
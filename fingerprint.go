@@ -0,0 +1,84 @@
+package errors
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/fnv"
+)
+
+// FingerprintRules define what error fingerprints are made of.
+// By default a fingerprint covers template ids (or codes), kinds and call sites of the whole error chain,
+// formatted arguments and line numbers are ignored. Errors of other packages are covered by their types and messages.
+type FingerprintRules struct {
+	// WithoutLocation drops call sites out of fingerprints.
+	WithoutLocation bool
+	// Details lists detail keys which values are included into fingerprints.
+	Details []string
+}
+
+// DefaultFingerprintRules are used by Fingerprint.
+var DefaultFingerprintRules FingerprintRules
+
+// Fingerprint returns a stable hash used to group occurrences of the same error.
+func Fingerprint(err error) string {
+	return DefaultFingerprintRules.Fingerprint(err)
+}
+
+func (self FingerprintRules) Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var h = fnv.New64a()
+
+	self.write(h, err)
+
+	if len(self.Details) > 0 {
+		var details = convert(err).Details()
+		for _, k := range self.Details {
+			_, _ = fmt.Fprintf(h, "%s=%s;", k, details[k])
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (self FingerprintRules) write(h hash.Hash, err error) {
+	for err != nil {
+		var t, ok = err.(*implementation)
+		if !ok {
+			// errors of other packages have no templates, their messages are all there is to tell them apart
+			_, _ = fmt.Fprintf(h, "%T:%s;", err, err.Error())
+
+			if multi, ok := err.(interface{ Unwrap() []error }); ok {
+				for _, e := range multi.Unwrap() {
+					self.write(h, e)
+				}
+
+				return
+			}
+
+			err = errors.Unwrap(err)
+
+			continue
+		}
+
+		if t.code != "" {
+			_, _ = fmt.Fprintf(h, "%d:%s", t.kind, t.code)
+		} else {
+			_, _ = fmt.Fprintf(h, "%d:%d", t.kind, t.id)
+		}
+
+		if !self.WithoutLocation {
+			// relative paths keep fingerprints equal across build machines
+			var loc = t.location.resolve()
+			_, _ = fmt.Fprintf(h, "@%s:%s", loc.relative(), loc.function)
+		}
+
+		_, _ = h.Write([]byte{';'})
+
+		err = t.previous
+	}
+}
@@ -0,0 +1,79 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+var fingerprintFactory = NewNotFoundFactory("user %d not found")
+
+func fingerprintCallSite(id int) Error {
+	return fingerprintFactory.New(id)
+}
+
+func (suite *ErrorsSuite) TestFingerprint() {
+	var (
+		first  = fingerprintCallSite(1)
+		second = fingerprintCallSite(2)
+		other  = fingerprintFactory.New(1)
+	)
+
+	suite.Require().Empty(Fingerprint(nil))
+	suite.Require().Len(Fingerprint(first), 16)
+	suite.Require().Equal(Fingerprint(first), Fingerprint(second))
+	suite.Require().NotEqual(Fingerprint(first), Fingerprint(fingerprintCallSite(1).Wrap(errors.New("kek"))))
+
+	var rules = FingerprintRules{WithoutLocation: true}
+	suite.Require().Equal(rules.Fingerprint(first), rules.Fingerprint(other))
+
+	if stackCapture {
+		suite.Require().NotEqual(Fingerprint(first), Fingerprint(other))
+	}
+}
+
+func (suite *ErrorsSuite) TestFingerprintForeign() {
+	var (
+		eof     = io.EOF
+		refused = errors.New("connection refused")
+	)
+
+	suite.Require().NotEqual(Fingerprint(eof), Fingerprint(refused))
+	suite.Require().NotEqual(Fingerprint(From(eof)), Fingerprint(From(refused)))
+	suite.Require().Equal(Fingerprint(From(refused)), Fingerprint(From(errors.New("connection refused"))))
+	suite.Require().False(Is(From(eof), From(refused)))
+}
+
+func (suite *ErrorsSuite) TestFingerprintCode() {
+	var (
+		first  = WithCode(NewNotFoundFactory("user %d not found"), "USER-404")
+		second = WithCode(NewNotFoundFactory("user %s is missing"), "USER-404")
+		rules  = FingerprintRules{WithoutLocation: true}
+	)
+
+	suite.Require().Equal(rules.Fingerprint(first.New(1)), rules.Fingerprint(second.New("kek")))
+}
+
+func (suite *ErrorsSuite) TestFingerprintDetails() {
+	var (
+		rules = FingerprintRules{Details: []string{"table"}}
+		errs  []Error
+	)
+
+	for _, table := range []string{"users", "users", "orders"} {
+		errs = append(errs, NewPersistenceError("no rows").WithDetails(map[string]string{"table": table, "id": table}))
+	}
+
+	suite.Require().Equal(rules.Fingerprint(errs[0]), rules.Fingerprint(errs[1]))
+	suite.Require().NotEqual(rules.Fingerprint(errs[0]), rules.Fingerprint(errs[2]))
+	suite.Require().Equal(Fingerprint(errs[0]), Fingerprint(errs[2]))
+}
+
+func (suite *ErrorsSuite) TestFingerprintJoin() {
+	var (
+		first  = Aggregate(NewTimeoutError("timed out"), fmt.Errorf("kek"))
+		second = Aggregate(NewTimeoutError("timed out"), NewNotFoundError("not found"))
+	)
+
+	suite.Require().NotEqual(Fingerprint(first), Fingerprint(second))
+}
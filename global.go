@@ -2,7 +2,6 @@ package errors
 
 import (
 	"errors"
-	"hash/fnv"
 )
 
 // TODO: adapters (sql, validation etc)
@@ -24,7 +23,22 @@ func From(err error) Error {
 		return t
 	}
 
+	var out = convert(err)
+
+	created(out)
+
+	return out
+}
+
+// convert is From without hooks and metrics, for errors which are read rather than created, e.g. reported ones.
+func convert(err error) *implementation {
+	if t, ok := err.(*implementation); ok {
+		return t
+	}
+
+	// errors of other packages are told apart by messages, see also Extract
 	return &implementation{
+		id:       errorId(err.Error()),
 		kind:     ErrKindGeneral,
 		message:  err.Error(),
 		previous: errors.Unwrap(err),
@@ -33,9 +47,12 @@ func From(err error) Error {
 
 func Is(err error, target any) bool {
 	switch t := target.(type) {
+	case *factory:
+		// since errors are compared by implementation.id and kind, the static error of the factory
+		// stands for any of its errors, creating one would call hooks and count metrics
+		return errors.Is(err, t.static)
+
 	case Factory:
-		// since errors will compare factory and factory's error by implementation.Id,
-		// we can just call new with no arguments
 		return errors.Is(err, t.New())
 
 	case error:
@@ -49,6 +66,15 @@ func Join(errs ...error) error {
 	return errors.Join(errs...)
 }
 
+// Code returns an error code set by WithCode, kind name is returned for errors with no code.
+func Code(err error) string {
+	if t, ok := err.(interface{ ErrorCode() string }); ok {
+		return t.ErrorCode()
+	}
+
+	return kindName(KindOf(err))
+}
+
 func Labels(err error) LabelList {
 	if t, ok := err.(*implementation); ok {
 		return t.labels
@@ -61,6 +87,25 @@ func IsUserFriendly(err error) bool {
 	return Labels(err).Has(LabelUserFriendly)
 }
 
+// DetailRetryAfter is a detail holding a duration (as in time.Duration.String) a retry makes sense after.
+const DetailRetryAfter = "retry_after"
+
+// IsRetryable reports whether an operation failed with err is worth retrying:
+// the error is labeled with LabelRetryable or is of a transient kind
+// (Infrastructure, ThirdParties, Timeout, LimitExceeded).
+func IsRetryable(err error) bool {
+	if Labels(err).Has(LabelRetryable) {
+		return true
+	}
+
+	switch KindOf(err) {
+	case ErrKindInfrastructure, ErrKindThirdParties, ErrKindTimeout, ErrKindLimitExceeded:
+		return true
+	}
+
+	return false
+}
+
 func In(err error, target ...any) bool {
 	for _, t := range target {
 		if Is(err, t) {
@@ -76,9 +121,44 @@ func As(err error, target any) bool {
 	return errors.As(err, target)
 }
 
+// templateIds caches ids of templates per call site, since templates are usually constants.
+var templateIds pcCache[templateIdEntry]
+
+type templateIdEntry struct {
+	template string
+	id       uint32
+}
+
+// templateId returns errorId of the template used at the call site.
+func templateId(pc uintptr, template string) uint32 {
+	if pc == 0 {
+		return errorId(template)
+	}
+
+	// comparison of constant strings is cheap, they share the pointer
+	if e, ok := templateIds.load(pc); ok && e.template == template {
+		return e.id
+	}
+
+	var id = errorId(template)
+	templateIds.store(pc, templateIdEntry{template: template, id: id})
+
+	return id
+}
+
+// errorId returns FNV-1 hash of the template, it is the same as hash/fnv.New32 without allocations.
 func errorId(template string) uint32 {
-	var hash = fnv.New32()
-	// this function never returns error, so we can ignore it
-	_, _ = hash.Write([]byte(template))
-	return hash.Sum32()
+	const (
+		offset32 = 2166136261
+		prime32  = 16777619
+	)
+
+	var hash uint32 = offset32
+
+	for i := 0; i < len(template); i++ {
+		hash *= prime32
+		hash ^= uint32(template[i])
+	}
+
+	return hash
 }
@@ -0,0 +1,92 @@
+// Package graphqlerrors renders errors as GraphQL response errors and rebuilds them back.
+// Only user-friendly messages are exposed, kind, code, template id, labels and details go to extensions.
+package graphqlerrors
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/aerario/errors"
+)
+
+// Error is an error of a GraphQL response as defined by the specification.
+type Error struct {
+	Message    string      `json:"message"`
+	Path       []any       `json:"path,omitempty"`
+	Locations  []Location  `json:"locations,omitempty"`
+	Extensions *Extensions `json:"extensions,omitempty"`
+}
+
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type Extensions struct {
+	Code    string            `json:"code"`
+	Kind    string            `json:"kind"`
+	Id      string            `json:"id,omitempty"` // template id, see errors.Inject
+	Labels  []string          `json:"labels,omitempty"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// New renders the error occurred at the path of the response, path elements are field names and list indexes.
+func New(err error, path []any, locations ...Location) Error {
+	var (
+		e       = errors.From(err)
+		carrier = errors.MapCarrier{}
+	)
+
+	errors.Inject(e, carrier)
+
+	var ext = &Extensions{
+		Code:    errors.Code(e),
+		Kind:    errors.KindOf(e).String(),
+		Id:      carrier[errors.CarrierKeyId],
+		Details: e.Details(),
+	}
+
+	for _, l := range e.Labels() {
+		ext.Labels = append(ext.Labels, string(l))
+	}
+
+	return Error{
+		Message:    e.Error(),
+		Path:       path,
+		Locations:  locations,
+		Extensions: ext,
+	}
+}
+
+// Decode rebuilds an error out of a GraphQL response error. Errors of other services carry no extensions,
+// their messages are meant for clients, so they are user-friendly.
+func Decode(in Error) errors.Error {
+	var ext = in.Extensions
+	if ext == nil {
+		ext = &Extensions{Labels: []string{string(errors.LabelUserFriendly)}}
+	}
+
+	var carrier = errors.MapCarrier{
+		errors.CarrierKeyKind:    errors.ParseKind(ext.Kind).String(),
+		errors.CarrierKeyId:      ext.Id,
+		errors.CarrierKeyMessage: url.QueryEscape(in.Message),
+	}
+
+	if ext.Code != "" && ext.Code != ext.Kind {
+		carrier[errors.CarrierKeyCode] = url.QueryEscape(ext.Code)
+	}
+
+	var labels = make([]string, 0, len(ext.Labels))
+	for _, l := range ext.Labels {
+		labels = append(labels, url.QueryEscape(l))
+	}
+
+	carrier[errors.CarrierKeyLabels] = strings.Join(labels, ",")
+
+	var err = errors.Extract(carrier)
+	if len(ext.Details) > 0 {
+		err = err.WithDetails(ext.Details)
+	}
+
+	return err
+}
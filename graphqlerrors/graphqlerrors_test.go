@@ -0,0 +1,69 @@
+package graphqlerrors
+
+import (
+	"encoding/json/v2"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/aerario/errors"
+)
+
+type GraphQLErrorsSuite struct {
+	suite.Suite
+}
+
+func TestGraphQLErrorsSuite(t *testing.T) {
+	suite.Run(t, new(GraphQLErrorsSuite))
+}
+
+func (suite *GraphQLErrorsSuite) TestNew() {
+	var err = errors.WithCode(errors.NewNotFoundFactory("user %d not found"), "USER-404").WithLabels("users").New(42).
+		WithDetails(map[string]string{"id": "42"})
+
+	var rendered = New(err, []any{"users", 1, "friend"}, Location{Line: 3, Column: 5})
+	suite.Require().NotEmpty(rendered.Extensions.Id)
+
+	var data, e = json.Marshal(rendered)
+	suite.Require().NoError(e)
+	suite.Require().JSONEq(`{
+		"message": "user 42 not found",
+		"path": ["users", 1, "friend"],
+		"locations": [{"line": 3, "column": 5}],
+		"extensions": {
+			"code": "USER-404",
+			"kind": "NotFound",
+			"id": "`+rendered.Extensions.Id+`",
+			"labels": ["user-friendly", "users"],
+			"details": {"id": "42"}
+		}
+	}`, string(data))
+
+	var decoded Error
+	suite.Require().NoError(json.Unmarshal(data, &decoded))
+
+	var rebuilt = Decode(decoded)
+	suite.Require().Equal(errors.ErrKindNotFound, errors.KindOf(rebuilt))
+	suite.Require().Equal("USER-404", errors.Code(rebuilt))
+	suite.Require().Equal("user 42 not found", rebuilt.Error())
+	suite.Require().Equal(errors.LabelList{errors.LabelUserFriendly, "users"}, rebuilt.Labels())
+	suite.Require().Equal(map[string]string{"id": "42"}, rebuilt.Details())
+	suite.Require().True(errors.Is(rebuilt, errors.NewNotFoundFactory("user %d not found")))
+	suite.Require().False(errors.Is(rebuilt, errors.NewNotFoundFactory("%s")))
+	suite.Require().False(errors.Is(rebuilt, Decode(New(errors.NewNotFoundError("order %d not found", 1), nil))))
+}
+
+func (suite *GraphQLErrorsSuite) TestNotUserFriendly() {
+	var rendered = New(errors.NewPersistenceError("dial tcp: connection refused"), nil)
+	suite.Require().Equal(errors.DefaultUserFriendlyError, rendered.Message)
+	suite.Require().Equal("Persistence", rendered.Extensions.Kind)
+
+	var rebuilt = Decode(rendered)
+	suite.Require().Equal(errors.ErrKindPersistence, errors.KindOf(rebuilt))
+	suite.Require().False(errors.IsUserFriendly(rebuilt))
+
+	rebuilt = Decode(Error{Message: "kek"})
+	suite.Require().Equal(errors.ErrKindGeneral, errors.KindOf(rebuilt))
+	suite.Require().Equal("kek", rebuilt.Error())
+	suite.Require().False(errors.Is(rebuilt, Decode(Error{Message: "bek"})))
+}
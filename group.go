@@ -0,0 +1,133 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const aggregateTemplate = "%d errors occurred"
+
+// Aggregate joins errors into a single Error of the most severe kind.
+// Nil errors are skipped, nil is returned if there are no errors at all.
+func Aggregate(errs ...error) Error {
+	var (
+		kinds  = make([]Kind, 0, len(errs))
+		causes = make([]error, 0, len(errs))
+	)
+
+	for _, err := range errs {
+		if err != nil {
+			kinds = append(kinds, KindOf(err))
+			causes = append(causes, err)
+		}
+	}
+
+	switch len(causes) {
+	case 0:
+		return nil
+	case 1:
+		return From(causes[0])
+	}
+
+	return &implementation{
+		id:       errorId(aggregateTemplate),
+		kind:     MostSevere(kinds...),
+		message:  fmt.Sprintf(aggregateTemplate, len(causes)),
+		previous: errors.Join(causes...),
+	}
+}
+
+// Group is a collection of goroutines working on subtasks of the same task.
+// Unlike errgroup it collects all the failures, panics are converted into errors.
+// A zero Group is valid, has no limit on the number of active goroutines and does not cancel anything.
+type Group struct {
+	cancel   context.CancelCauseFunc
+	cancelOn func(Kind) bool
+	sem      chan struct{}
+	wg       sync.WaitGroup
+
+	mu   sync.Mutex
+	errs []error
+}
+
+// NewGroup returns a new Group and an associated context derived from ctx.
+// The derived context is canceled when a goroutine fails with an error
+// the cancel policy accepts (server faults by default) or when Wait returns.
+func NewGroup(ctx context.Context) (*Group, context.Context) {
+	var ctx2, cancel = context.WithCancelCause(ctx)
+
+	return &Group{cancel: cancel}, ctx2
+}
+
+// SetLimit limits the number of active goroutines in the group, negative value means no limit.
+// It must not be called while any goroutine in the group is active.
+func (self *Group) SetLimit(n int) {
+	if n < 0 {
+		self.sem = nil
+		return
+	}
+
+	self.sem = make(chan struct{}, n)
+}
+
+// SetCancelPolicy sets a function that decides by kind whether a failure cancels the group.
+// It must not be called while any goroutine in the group is active.
+func (self *Group) SetCancelPolicy(policy func(Kind) bool) {
+	self.cancelOn = policy
+}
+
+// Go calls fn in a new goroutine, blocking until a new goroutine can be added without exceeding the limit.
+func (self *Group) Go(fn func() error) {
+	if self.sem != nil {
+		self.sem <- struct{}{}
+	}
+
+	self.wg.Add(1)
+
+	go func() {
+		defer self.done()
+
+		if err := Catch(fn); err != nil {
+			self.fail(err)
+		}
+	}()
+}
+
+// Wait blocks until all goroutines have returned and aggregates their errors.
+func (self *Group) Wait() Error {
+	self.wg.Wait()
+
+	if self.cancel != nil {
+		self.cancel(nil)
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	return Aggregate(self.errs...)
+}
+
+func (self *Group) done() {
+	if self.sem != nil {
+		<-self.sem
+	}
+
+	self.wg.Done()
+}
+
+func (self *Group) fail(err error) {
+	self.mu.Lock()
+	self.errs = append(self.errs, err)
+	self.mu.Unlock()
+
+	var cancelOn = self.cancelOn
+	if cancelOn == nil {
+		cancelOn = IsServerFault
+	}
+
+	if self.cancel != nil && cancelOn(KindOf(err)) {
+		self.cancel(err)
+	}
+}
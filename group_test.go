@@ -0,0 +1,144 @@
+package errors
+
+import (
+	"context"
+	"encoding/json/v2"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+func (suite *ErrorsSuite) TestMostSevere() {
+	suite.Require().Equal(ErrKindGeneral, MostSevere())
+	suite.Require().Equal(ErrKindNotFound, MostSevere(ErrKindValidation, ErrKindNotFound))
+	suite.Require().Equal(ErrKindInfrastructure, MostSevere(ErrKindValidation, ErrKindInfrastructure, ErrKindTimeout))
+}
+
+func (suite *ErrorsSuite) TestAggregate() {
+	var (
+		notFound = NewNotFoundError("not found")
+		infra    = NewInfrastructureError("no connection")
+		goErr    = errors.New("kek bek")
+	)
+
+	suite.Require().Nil(Aggregate())
+	suite.Require().Nil(Aggregate(nil, nil))
+	suite.Require().Equal(notFound, Aggregate(nil, notFound))
+
+	var err = Aggregate(notFound, nil, infra, goErr)
+	suite.Require().Error(err)
+	suite.Require().Equal(ErrKindInfrastructure, KindOf(err))
+	suite.Require().True(Is(err, notFound))
+	suite.Require().True(Is(err, infra))
+	suite.Require().True(Is(err, goErr))
+}
+
+func (suite *ErrorsSuite) TestAggregateWalk() {
+	var (
+		first  = NewNotFoundFactory("user %d not found").New(1).WithDetails(map[string]string{"user": "1"})
+		second = NewValidationFactory("invalid email").New().WithDetails(map[string]string{"field": "email"})
+		hidden = NewInfrastructureError("no connection").Wrap(errors.New("dial tcp"))
+		err    = NewBadRequestFactory("bad request").New().Wrap(Aggregate(first, hidden, second))
+	)
+
+	suite.Require().Equal("bad request: user 1 not found; invalid email", err.Error())
+	suite.Require().Equal("bad request: 3 errors occurred: user 1 not found; no connection: dial tcp; invalid email", Raw(err).Error())
+	suite.Require().Equal(map[string]string{"user": "1", "field": "email"}, err.Details())
+
+	var frames []stackTraceFrame
+	suite.Require().NoError(json.Unmarshal(err.(Stacker).StackTrace(), &frames))
+
+	var messages []string
+	for _, f := range frames {
+		messages = append(messages, f.Error)
+	}
+
+	suite.Require().Equal([]string{
+		"bad request", "3 errors occurred", "user 1 not found", "no connection", "dial tcp", "invalid email",
+	}, messages)
+
+	if stackCapture {
+		suite.Require().Contains(frames[2].Location, "group_test.go")
+		suite.Require().Contains(frames[5].Location, "group_test.go")
+	}
+}
+
+func (suite *ErrorsSuite) TestGroup() {
+	var group, ctx = NewGroup(context.Background())
+
+	group.Go(func() error { return NewValidationError("invalid") })
+	group.Go(func() error { return NewNotFoundError("not found") })
+	group.Go(func() error { return nil })
+
+	var err = group.Wait()
+	suite.Require().Error(err)
+	suite.Require().Equal(ErrKindNotFound, KindOf(err))
+	suite.Require().Equal(context.Canceled, context.Cause(ctx))
+}
+
+func (suite *ErrorsSuite) TestGroupCancel() {
+	var (
+		group, ctx = NewGroup(context.Background())
+		infra      = NewInfrastructureError("no connection")
+	)
+
+	group.Go(func() error { return infra })
+	group.Go(func() error {
+		<-ctx.Done()
+		return NewTimeoutError("canceled")
+	})
+
+	var err = group.Wait()
+	suite.Require().Equal(ErrKindInfrastructure, KindOf(err))
+	suite.Require().True(Is(err, infra))
+	suite.Require().Equal(infra, context.Cause(ctx))
+}
+
+func (suite *ErrorsSuite) TestGroupCancelPolicy() {
+	var group, ctx = NewGroup(context.Background())
+
+	group.SetCancelPolicy(func(kind Kind) bool { return kind == ErrKindValidation })
+	group.Go(func() error { return NewValidationError("invalid") })
+
+	suite.Require().Equal(ErrKindValidation, KindOf(group.Wait()))
+	suite.Require().Error(context.Cause(ctx))
+}
+
+func (suite *ErrorsSuite) TestGroupPanic() {
+	var group Group
+
+	group.Go(func() error { panic("kek") })
+
+	var err = group.Wait()
+	suite.Require().True(Labels(err).Has(LabelPanic))
+}
+
+func (suite *ErrorsSuite) TestGroupLimit() {
+	var (
+		group          Group
+		active, maxAct atomic.Int32
+	)
+
+	group.SetLimit(2)
+
+	for range 10 {
+		group.Go(func() error {
+			var n = active.Add(1)
+			defer active.Add(-1)
+
+			for {
+				var m = maxAct.Load()
+				if n <= m || maxAct.CompareAndSwap(m, n) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+
+			return nil
+		})
+	}
+
+	suite.Require().NoError(group.Wait())
+	suite.Require().LessOrEqual(maxAct.Load(), int32(2))
+}
@@ -0,0 +1,292 @@
+// Package grpcerrors maps errors to canonical gRPC codes and google.rpc.Status JSON without depending on grpc-go.
+// Gluing it to grpc-go takes a few lines:
+//
+//	var s = grpcerrors.FromError(err)
+//	return status.Error(codes.Code(s.Code), s.Message)
+//
+// Details with FieldDetailPrefix become BadRequest field violations, errors.DetailRetryAfter becomes RetryInfo,
+// other details become ErrorInfo metadata, error code becomes ErrorInfo reason.
+// Kind, template id and labels go to ErrorInfo metadata under errors.CarrierKeyKind, errors.CarrierKeyId
+// and errors.CarrierKeyLabels, so that a rebuilt error matches the original factory only.
+// Several kinds share gRPC codes, the kind of metadata is preferred over the status code.
+package grpcerrors
+
+import (
+	"encoding/json/v2"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aerario/errors"
+)
+
+// Code is a canonical gRPC status code.
+type Code uint32
+
+const (
+	OK Code = iota
+	Canceled
+	Unknown
+	InvalidArgument
+	DeadlineExceeded
+	NotFound
+	AlreadyExists
+	PermissionDenied
+	ResourceExhausted
+	FailedPrecondition
+	Aborted
+	OutOfRange
+	Unimplemented
+	Internal
+	Unavailable
+	DataLoss
+	Unauthenticated
+)
+
+// Type URLs of google.rpc error details.
+const (
+	TypeErrorInfo        = "type.googleapis.com/google.rpc.ErrorInfo"
+	TypeBadRequest       = "type.googleapis.com/google.rpc.BadRequest"
+	TypeRetryInfo        = "type.googleapis.com/google.rpc.RetryInfo"
+	TypeLocalizedMessage = "type.googleapis.com/google.rpc.LocalizedMessage"
+)
+
+// FieldDetailPrefix prefixes details describing invalid request fields, e.g. "field.email".
+const FieldDetailPrefix = "field."
+
+var (
+	// Domain is a domain of ErrorInfo details.
+	Domain = ""
+	// Locale is a locale of LocalizedMessage details.
+	Locale = "en-US"
+)
+
+// KindToCode maps error kinds to gRPC codes, unknown kinds are mapped to Unknown.
+var KindToCode = map[errors.Kind]Code{
+	errors.ErrKindGeneral:        Unknown,
+	errors.ErrKindAuthentication: Unauthenticated,
+	errors.ErrKindAuthorization:  PermissionDenied,
+	errors.ErrKindBadRequest:     InvalidArgument,
+	errors.ErrKindValidation:     InvalidArgument,
+	errors.ErrKindNotFound:       NotFound,
+	errors.ErrKindAlreadyExists:  AlreadyExists,
+	errors.ErrKindLimitExceeded:  ResourceExhausted,
+	errors.ErrKindInconsistent:   Internal,
+	errors.ErrKindPersistence:    Internal,
+	errors.ErrKindInfrastructure: Unavailable,
+	errors.ErrKindThirdParties:   Unavailable,
+	errors.ErrKindTimeout:        DeadlineExceeded,
+}
+
+// CodeToKind maps gRPC codes to error kinds, unknown codes are mapped to General.
+var CodeToKind = map[Code]errors.Kind{
+	Canceled:           errors.ErrKindTimeout,
+	Unknown:            errors.ErrKindGeneral,
+	InvalidArgument:    errors.ErrKindValidation,
+	DeadlineExceeded:   errors.ErrKindTimeout,
+	NotFound:           errors.ErrKindNotFound,
+	AlreadyExists:      errors.ErrKindAlreadyExists,
+	PermissionDenied:   errors.ErrKindAuthorization,
+	ResourceExhausted:  errors.ErrKindLimitExceeded,
+	FailedPrecondition: errors.ErrKindBadRequest,
+	Aborted:            errors.ErrKindInconsistent,
+	OutOfRange:         errors.ErrKindValidation,
+	Unimplemented:      errors.ErrKindBadRequest,
+	Internal:           errors.ErrKindGeneral,
+	Unavailable:        errors.ErrKindInfrastructure,
+	DataLoss:           errors.ErrKindPersistence,
+	Unauthenticated:    errors.ErrKindAuthentication,
+}
+
+func FromKind(kind errors.Kind) Code {
+	if code, ok := KindToCode[kind]; ok {
+		return code
+	}
+
+	return Unknown
+}
+
+func ToKind(code Code) errors.Kind {
+	if kind, ok := CodeToKind[code]; ok {
+		return kind
+	}
+
+	return errors.ErrKindGeneral
+}
+
+// Status mirrors google.rpc.Status JSON.
+type Status struct {
+	Code    Code     `json:"code"`
+	Message string   `json:"message"`
+	Details []Detail `json:"details,omitempty"`
+}
+
+// Detail is a union of supported google.rpc error details distinguished by Type.
+type Detail struct {
+	Type string `json:"@type"`
+
+	// ErrorInfo
+	Reason   string            `json:"reason,omitempty"`
+	Domain   string            `json:"domain,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// BadRequest
+	FieldViolations []FieldViolation `json:"fieldViolations,omitempty"`
+
+	// RetryInfo, a duration in protobuf JSON format, e.g. "1.5s"
+	RetryDelay string `json:"retryDelay,omitempty"`
+
+	// LocalizedMessage
+	Locale  string `json:"locale,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// FromError makes a status out of the error, nil error makes OK status.
+func FromError(err error) Status {
+	if err == nil {
+		return Status{Code: OK}
+	}
+
+	var (
+		e       = errors.From(err)
+		status  = Status{Code: FromKind(errors.KindOf(e)), Message: e.Error()}
+		info    = Detail{Type: TypeErrorInfo, Reason: errors.Code(e), Domain: Domain}
+		details = e.Details()
+		fields  []FieldViolation
+		carrier = errors.MapCarrier{}
+	)
+
+	errors.Inject(e, carrier)
+
+	info.Metadata = map[string]string{
+		errors.CarrierKeyKind: carrier[errors.CarrierKeyKind],
+		errors.CarrierKeyId:   carrier[errors.CarrierKeyId],
+	}
+
+	if labels, ok := carrier[errors.CarrierKeyLabels]; ok {
+		info.Metadata[errors.CarrierKeyLabels] = labels
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(details)) {
+		var v = details[k]
+
+		if field, ok := strings.CutPrefix(k, FieldDetailPrefix); ok {
+			fields = append(fields, FieldViolation{Field: field, Description: v})
+			continue
+		}
+
+		if k == errors.DetailRetryAfter {
+			if d, err := time.ParseDuration(v); err == nil {
+				status.Details = append(status.Details, Detail{Type: TypeRetryInfo, RetryDelay: formatDuration(d)})
+				continue
+			}
+		}
+
+		info.Metadata[k] = v
+	}
+
+	status.Details = append([]Detail{info}, status.Details...)
+
+	if len(fields) > 0 {
+		status.Details = append(status.Details, Detail{Type: TypeBadRequest, FieldViolations: fields})
+	}
+
+	if errors.IsUserFriendly(e) {
+		status.Details = append(status.Details, Detail{Type: TypeLocalizedMessage, Locale: Locale, Message: e.Error()})
+	}
+
+	return status
+}
+
+// ToError rebuilds an error out of the status, nil is returned for OK status.
+// Statuses of other services carry no labels, their errors are user-friendly if there is a LocalizedMessage.
+func ToError(status Status) errors.Error {
+	if status.Code == OK {
+		return nil
+	}
+
+	var (
+		message = status.Message
+		details = make(map[string]string)
+		carrier = errors.MapCarrier{errors.CarrierKeyKind: ToKind(status.Code).String()}
+		local   bool
+	)
+
+	for _, d := range status.Details {
+		switch d.Type {
+		case TypeErrorInfo:
+			if d.Reason != "" {
+				carrier[errors.CarrierKeyCode] = url.QueryEscape(d.Reason)
+			}
+
+			for k, v := range d.Metadata {
+				switch k {
+				case errors.CarrierKeyKind, errors.CarrierKeyId, errors.CarrierKeyLabels:
+					carrier[k] = v
+				default:
+					details[k] = v
+				}
+			}
+		case TypeBadRequest:
+			for _, f := range d.FieldViolations {
+				details[FieldDetailPrefix+f.Field] = f.Description
+			}
+		case TypeRetryInfo:
+			if delay, err := parseDuration(d.RetryDelay); err == nil {
+				details[errors.DetailRetryAfter] = delay.String()
+			}
+		case TypeLocalizedMessage:
+			message, local = d.Message, true
+		}
+	}
+
+	if _, ok := carrier[errors.CarrierKeyLabels]; !ok && local {
+		carrier[errors.CarrierKeyLabels] = string(errors.LabelUserFriendly)
+	}
+
+	carrier[errors.CarrierKeyMessage] = url.QueryEscape(message)
+
+	var err = errors.Extract(carrier)
+	if len(details) > 0 {
+		err = err.WithDetails(details)
+	}
+
+	return err
+}
+
+// Encode marshals a status of the error.
+func Encode(err error) ([]byte, error) {
+	return json.Marshal(FromError(err))
+}
+
+// Decode unmarshals a status and rebuilds the error.
+func Decode(data []byte) (errors.Error, error) {
+	var status Status
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, err
+	}
+
+	return ToError(status), nil
+}
+
+// formatDuration formats a duration as google.protobuf.Duration JSON.
+func formatDuration(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	var seconds, err = strconv.ParseFloat(strings.TrimSuffix(s, "s"), 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
@@ -0,0 +1,158 @@
+package grpcerrors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/aerario/errors"
+)
+
+type GRPCErrorsSuite struct {
+	suite.Suite
+}
+
+func TestGRPCErrorsSuite(t *testing.T) {
+	suite.Run(t, new(GRPCErrorsSuite))
+}
+
+func (suite *GRPCErrorsSuite) TestKinds() {
+	var tests = []struct {
+		kind errors.Kind
+		code Code
+	}{
+		{kind: errors.ErrKindNotFound, code: NotFound},
+		{kind: errors.ErrKindValidation, code: InvalidArgument},
+		{kind: errors.ErrKindAuthentication, code: Unauthenticated},
+		{kind: errors.ErrKindAuthorization, code: PermissionDenied},
+		{kind: errors.ErrKindLimitExceeded, code: ResourceExhausted},
+		{kind: errors.ErrKindTimeout, code: DeadlineExceeded},
+		{kind: errors.ErrKindAlreadyExists, code: AlreadyExists},
+		{kind: errors.ErrKindInfrastructure, code: Unavailable},
+		{kind: errors.ErrKindGeneral, code: Unknown},
+	}
+
+	for _, t := range tests {
+		suite.Require().Equal(t.code, FromKind(t.kind))
+		suite.Require().Equal(t.kind, ToKind(t.code))
+	}
+
+	suite.Require().Equal(Unknown, FromKind(errors.Kind(100500)))
+	suite.Require().Equal(errors.ErrKindGeneral, ToKind(Code(100500)))
+}
+
+func (suite *GRPCErrorsSuite) TestFromError() {
+	var err = errors.WithCode(errors.NewValidationFactory("invalid user"), "USER-INVALID").New().WithDetails(map[string]string{
+		"field.email":           "must be an email",
+		errors.DetailRetryAfter: "1.5s",
+		"user_id":               "42",
+	})
+
+	var carrier = errors.MapCarrier{}
+	errors.Inject(err, carrier)
+
+	suite.Require().Equal(Status{
+		Code:    InvalidArgument,
+		Message: "invalid user",
+		Details: []Detail{
+			{Type: TypeErrorInfo, Reason: "USER-INVALID", Metadata: map[string]string{
+				errors.CarrierKeyKind:   "Validation",
+				errors.CarrierKeyId:     carrier[errors.CarrierKeyId],
+				errors.CarrierKeyLabels: carrier[errors.CarrierKeyLabels],
+				"user_id":               "42",
+			}},
+			{Type: TypeRetryInfo, RetryDelay: "1.5s"},
+			{Type: TypeBadRequest, FieldViolations: []FieldViolation{{Field: "email", Description: "must be an email"}}},
+			{Type: TypeLocalizedMessage, Locale: "en-US", Message: "invalid user"},
+		},
+	}, FromError(err))
+
+	suite.Require().Equal(Status{Code: OK}, FromError(nil))
+}
+
+func (suite *GRPCErrorsSuite) TestEncodeDecode() {
+	var (
+		notFound = errors.WithCode(errors.NewNotFoundFactory("user %d not found"), "USER-404")
+		original = notFound.New(42).WithDetails(map[string]string{
+			"field.id":              "unknown",
+			errors.DetailRetryAfter: "2s",
+			"tenant":                "acme",
+		})
+	)
+
+	var data, err = Encode(original)
+	suite.Require().NoError(err)
+	suite.Require().Contains(string(data), `"@type":"type.googleapis.com/google.rpc.ErrorInfo"`)
+	suite.Require().Contains(string(data), `"code":5`)
+
+	var decoded errors.Error
+	decoded, err = Decode(data)
+	suite.Require().NoError(err)
+	suite.Require().Equal(errors.ErrKindNotFound, errors.KindOf(decoded))
+	suite.Require().Equal("USER-404", errors.Code(decoded))
+	suite.Require().Equal("user 42 not found", decoded.Error())
+	suite.Require().Equal(original.Details(), decoded.Details())
+	suite.Require().True(errors.IsUserFriendly(decoded))
+	suite.Require().True(errors.Is(decoded, notFound))
+	suite.Require().False(errors.Is(decoded, errors.NewNotFoundFactory("%s")))
+
+	var other errors.Error
+	other, err = Decode(data)
+	suite.Require().NoError(err)
+	suite.Require().True(errors.Is(decoded, other))
+
+	other = ToError(FromError(errors.NewNotFoundError("order %d not found", 42)))
+	suite.Require().False(errors.Is(decoded, other))
+
+	_, err = Decode([]byte("kek"))
+	suite.Require().Error(err)
+
+	decoded, err = Decode([]byte(`{"code":0}`))
+	suite.Require().NoError(err)
+	suite.Require().Nil(decoded)
+}
+
+func (suite *GRPCErrorsSuite) TestKindRoundTrip() {
+	for kind := errors.ErrKindGeneral; kind <= errors.ErrKindTimeout; kind++ {
+		var factory = errors.NewFactory(kind, "user %d failed")
+
+		var data, err = Encode(factory.New(42))
+		suite.Require().NoError(err)
+
+		var decoded errors.Error
+		decoded, err = Decode(data)
+		suite.Require().NoError(err)
+		suite.Require().Equal(kind, errors.KindOf(decoded), kind.String())
+		suite.Require().True(errors.Is(decoded, factory), kind.String())
+	}
+}
+
+func (suite *GRPCErrorsSuite) TestNotUserFriendly() {
+	var status = FromError(errors.NewInfrastructureError("dial tcp: connection refused"))
+	suite.Require().Equal(Unavailable, status.Code)
+	suite.Require().Equal(errors.DefaultUserFriendlyError, status.Message)
+	suite.Require().Len(status.Details, 1)
+	suite.Require().Equal("Infrastructure", status.Details[0].Reason)
+	suite.Require().NotContains(status.Details[0].Metadata, errors.CarrierKeyLabels)
+
+	var err = ToError(status)
+	suite.Require().False(errors.IsUserFriendly(err))
+	suite.Require().Equal(errors.ErrKindInfrastructure, errors.KindOf(err))
+	suite.Require().Equal(errors.DefaultUserFriendlyError, err.Error())
+}
+
+func (suite *GRPCErrorsSuite) TestForeignStatus() {
+	var (
+		first  = ToError(Status{Code: NotFound, Message: "user not found"})
+		second = ToError(Status{Code: NotFound, Message: "order not found"})
+		local  = ToError(Status{Code: NotFound, Message: "order not found", Details: []Detail{
+			{Type: TypeLocalizedMessage, Locale: "en-US", Message: "order not found"},
+		}})
+	)
+
+	suite.Require().False(errors.Is(first, second))
+	suite.Require().True(errors.Is(second, local))
+	suite.Require().False(errors.IsUserFriendly(first))
+	suite.Require().True(errors.IsUserFriendly(local))
+	suite.Require().Equal("order not found", local.Error())
+}
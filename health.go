@@ -0,0 +1,267 @@
+package errors
+
+import (
+	"encoding/json/v2"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LabelDependencyPrefix prefixes labels naming a dependency that has caused an error.
+const LabelDependencyPrefix = "dependency:"
+
+// DependencyLabel returns a label naming a dependency (database, queue, remote service etc.).
+func DependencyLabel(name string) Label {
+	return Label(LabelDependencyPrefix + name)
+}
+
+// HealthStatus is a status of a dependency.
+type HealthStatus string
+
+const (
+	HealthOK       HealthStatus = "ok"
+	HealthDegraded HealthStatus = "degraded"
+	HealthDown     HealthStatus = "down"
+)
+
+// HealthThresholds are error rates (errors per second) a dependency is considered degraded or down at.
+type HealthThresholds struct {
+	Degraded float64
+	Down     float64
+}
+
+// HealthKinds are kinds of errors which indicate a dependency failure.
+var HealthKinds = []Kind{
+	ErrKindInfrastructure,
+	ErrKindPersistence,
+	ErrKindThirdParties,
+	ErrKindTimeout,
+}
+
+// healthBuckets is a number of buckets a sliding window is split to.
+const healthBuckets = 10
+
+// DependencyHealth is a status of a dependency along with error rates of each kind.
+type DependencyHealth struct {
+	Status HealthStatus       `json:"status"`
+	Rates  map[string]float64 `json:"rates"`
+}
+
+// HealthChange is sent to subscribers when a dependency status changes.
+type HealthChange struct {
+	Dependency string       `json:"dependency"`
+	From       HealthStatus `json:"from"`
+	To         HealthStatus `json:"to"`
+}
+
+// HealthTracker derives dependency statuses out of rates of reported errors.
+// It is a Sink, register it in a reporter to consume errors. Only errors of HealthKinds
+// labeled with DependencyLabel are taken into account. Statuses are evaluated on every
+// consumed error and on every Status call, so subscribers are notified about recovery
+// when the tracker is queried (e.g. by a readiness probe) or the next error arrives.
+type HealthTracker struct {
+	window     time.Duration
+	thresholds HealthThresholds
+	now        func() time.Time
+
+	mu           sync.Mutex
+	dependencies map[string]*dependencyWindow
+	subscribers  map[chan HealthChange]struct{}
+}
+
+type dependencyWindow struct {
+	status  HealthStatus
+	buckets [healthBuckets]healthBucket
+}
+
+type healthBucket struct {
+	start  time.Time
+	counts map[Kind]int
+}
+
+// NewHealthTracker makes a tracker computing error rates over a sliding window of at least a second.
+func NewHealthTracker(window time.Duration, thresholds HealthThresholds) *HealthTracker {
+	return &HealthTracker{
+		window:       max(window, time.Second),
+		thresholds:   thresholds,
+		now:          time.Now,
+		dependencies: make(map[string]*dependencyWindow),
+		subscribers:  make(map[chan HealthChange]struct{}),
+	}
+}
+
+func (self *HealthTracker) Write(event Event) error {
+	var kind = ParseKind(event.Kind)
+	if !slices.Contains(HealthKinds, kind) {
+		return nil
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	var now = self.now()
+
+	for _, l := range event.Labels {
+		var name, ok = strings.CutPrefix(string(l), LabelDependencyPrefix)
+		if !ok {
+			continue
+		}
+
+		var dep = self.dependencies[name]
+		if dep == nil {
+			dep = &dependencyWindow{status: HealthOK}
+			self.dependencies[name] = dep
+		}
+
+		var bucket = dep.bucket(now, self.window)
+		bucket.counts[kind]++
+	}
+
+	self.evaluate(now)
+
+	return nil
+}
+
+// Status returns statuses of all the dependencies errors have been reported for.
+func (self *HealthTracker) Status() map[string]DependencyHealth {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	var now = self.now()
+
+	self.evaluate(now)
+
+	var out = make(map[string]DependencyHealth, len(self.dependencies))
+	for name, dep := range self.dependencies {
+		var rates = make(map[string]float64)
+		for kind, rate := range dep.rates(now, self.window) {
+			rates[kindName(kind)] = rate
+		}
+
+		out[name] = DependencyHealth{Status: dep.status, Rates: rates}
+	}
+
+	return out
+}
+
+// Subscribe returns a channel receiving status changes and a function to unsubscribe.
+// Changes are dropped if the channel buffer is full.
+func (self *HealthTracker) Subscribe() (<-chan HealthChange, func()) {
+	var ch = make(chan HealthChange, 16)
+
+	self.mu.Lock()
+	self.subscribers[ch] = struct{}{}
+	self.mu.Unlock()
+
+	var once sync.Once
+
+	return ch, func() {
+		once.Do(func() {
+			self.mu.Lock()
+			delete(self.subscribers, ch)
+			self.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// ServeHTTP serves statuses as JSON, it responds with 503 if any dependency is down.
+func (self *HealthTracker) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	var (
+		deps   = self.Status()
+		status = HealthOK
+	)
+
+	for _, dep := range deps {
+		if dep.Status == HealthDown {
+			status = HealthDown
+			break
+		}
+
+		if dep.Status == HealthDegraded {
+			status = HealthDegraded
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if status == HealthDown {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	_ = json.MarshalWrite(w, struct {
+		Status       HealthStatus                `json:"status"`
+		Dependencies map[string]DependencyHealth `json:"dependencies"`
+	}{
+		Status:       status,
+		Dependencies: deps,
+	})
+}
+
+// evaluate recomputes statuses and notifies subscribers, it must be called under the lock.
+func (self *HealthTracker) evaluate(now time.Time) {
+	for name, dep := range self.dependencies {
+		var total float64
+		for _, rate := range dep.rates(now, self.window) {
+			total += rate
+		}
+
+		var status = HealthOK
+
+		switch {
+		case self.thresholds.Down > 0 && total >= self.thresholds.Down:
+			status = HealthDown
+		case self.thresholds.Degraded > 0 && total >= self.thresholds.Degraded:
+			status = HealthDegraded
+		}
+
+		if status == dep.status {
+			continue
+		}
+
+		var change = HealthChange{Dependency: name, From: dep.status, To: status}
+		dep.status = status
+
+		for ch := range self.subscribers {
+			select {
+			case ch <- change:
+			default:
+			}
+		}
+	}
+}
+
+// bucket returns a bucket for the moment, resetting the stale one.
+func (self *dependencyWindow) bucket(now time.Time, window time.Duration) *healthBucket {
+	var (
+		size   = window / healthBuckets
+		start  = now.Truncate(size)
+		bucket = &self.buckets[int(start.UnixNano()/int64(size))%healthBuckets]
+	)
+
+	if !bucket.start.Equal(start) {
+		bucket.start = start
+		bucket.counts = make(map[Kind]int)
+	}
+
+	return bucket
+}
+
+// rates returns errors per second of each kind within the window.
+func (self *dependencyWindow) rates(now time.Time, window time.Duration) map[Kind]float64 {
+	var out = make(map[Kind]float64)
+
+	for _, bucket := range self.buckets {
+		if bucket.start.IsZero() || now.Sub(bucket.start) >= window {
+			continue
+		}
+
+		for kind, n := range bucket.counts {
+			out[kind] += float64(n) / window.Seconds()
+		}
+	}
+
+	return out
+}
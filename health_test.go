@@ -0,0 +1,79 @@
+package errors
+
+import (
+	"encoding/json/v2"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+func (suite *ErrorsSuite) TestHealthTracker() {
+	var (
+		now     = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		tracker = NewHealthTracker(10*time.Second, HealthThresholds{Degraded: 0.2, Down: 0.5})
+		db      = NewPersistenceFactory("query failed").WithLabels(DependencyLabel("db"))
+	)
+
+	tracker.now = func() time.Time { return now }
+
+	var changes, unsubscribe = tracker.Subscribe()
+	defer unsubscribe()
+
+	// client faults and errors with no dependency are ignored
+	for range 10 {
+		suite.Require().NoError(tracker.Write(NewEvent(NewValidationFactory("invalid").WithLabels(DependencyLabel("db")).New())))
+		suite.Require().NoError(tracker.Write(NewEvent(NewTimeoutError("timed out"))))
+	}
+
+	suite.Require().Empty(tracker.Status())
+
+	for range 2 {
+		suite.Require().NoError(tracker.Write(NewEvent(db.New())))
+	}
+
+	suite.Require().Equal(HealthChange{Dependency: "db", From: HealthOK, To: HealthDegraded}, <-changes)
+	suite.Require().Equal(DependencyHealth{
+		Status: HealthDegraded,
+		Rates:  map[string]float64{"Persistence": 0.2},
+	}, tracker.Status()["db"])
+
+	now = now.Add(5 * time.Second)
+	for range 3 {
+		suite.Require().NoError(tracker.Write(NewEvent(db.New())))
+	}
+
+	suite.Require().Equal(HealthChange{Dependency: "db", From: HealthDegraded, To: HealthDown}, <-changes)
+
+	// first errors leave the window
+	now = now.Add(6 * time.Second)
+	suite.Require().Equal(HealthDegraded, tracker.Status()["db"].Status)
+	suite.Require().Equal(HealthChange{Dependency: "db", From: HealthDown, To: HealthDegraded}, <-changes)
+
+	now = now.Add(10 * time.Second)
+	suite.Require().Equal(HealthOK, tracker.Status()["db"].Status)
+	suite.Require().Equal(HealthChange{Dependency: "db", From: HealthDegraded, To: HealthOK}, <-changes)
+}
+
+func (suite *ErrorsSuite) TestHealthTrackerHandler() {
+	var (
+		tracker = NewHealthTracker(time.Minute, HealthThresholds{Down: 1.0 / 60})
+		w       = httptest.NewRecorder()
+	)
+
+	tracker.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	suite.Require().NoError(tracker.Write(NewEvent(NewInfrastructureFactory("no connection").WithLabels(DependencyLabel("queue")).New())))
+
+	w = httptest.NewRecorder()
+	tracker.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	suite.Require().Equal(http.StatusServiceUnavailable, w.Code)
+
+	var body struct {
+		Status       HealthStatus                `json:"status"`
+		Dependencies map[string]DependencyHealth `json:"dependencies"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	suite.Require().Equal(HealthDown, body.Status)
+	suite.Require().Equal(HealthDown, body.Dependencies["queue"].Status)
+}
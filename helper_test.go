@@ -0,0 +1,45 @@
+package errors
+
+import (
+	"runtime"
+)
+
+var helperFactory = NewNotFoundFactory("%s not found")
+
+func notFoundHelper(id string) Error {
+	Helper()
+	return helperFactory.New(id)
+}
+
+func nestedHelper() Error {
+	Helper()
+	return notFoundHelper("kek")
+}
+
+func skipHelper() Error {
+	return NewSkip(1, ErrKindNotFound, "kek")
+}
+
+func factorySkipHelper() Error {
+	return helperFactory.NewSkip(1, "kek")
+}
+
+func (suite *ErrorsSuite) TestHelper() {
+	suite.requireStacks()
+
+	var tests = []func() (Error, int){
+		func() (Error, int) { var _, _, line, _ = runtime.Caller(0); return New(ErrKindNotFound, "kek"), line },
+		func() (Error, int) { var _, _, line, _ = runtime.Caller(0); return NewNotFoundError("kek"), line },
+		func() (Error, int) { var _, _, line, _ = runtime.Caller(0); return notFoundHelper("kek"), line },
+		func() (Error, int) { var _, _, line, _ = runtime.Caller(0); return nestedHelper(), line },
+		func() (Error, int) { var _, _, line, _ = runtime.Caller(0); return skipHelper(), line },
+		func() (Error, int) { var _, _, line, _ = runtime.Caller(0); return factorySkipHelper(), line },
+	}
+
+	for i, t := range tests {
+		var err, line = t()
+
+		suite.Require().Equal(line, err.(*implementation).location.resolve().line, i)
+		suite.Require().Equal("helper_test.go", err.(*implementation).location.relative(), i)
+	}
+}
@@ -0,0 +1,112 @@
+package errors
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	createHooks  hookList[func(Error)]
+	wrapHooks    hookList[func(Error, error)]
+	captureHooks hookList[func(Error) bool]
+)
+
+// OnCreate registers a hook called for every error made by New, Factory.New and From.
+// Hooks may enrich the error, e.g. add details. The returned function removes the hook.
+func OnCreate(hook func(Error)) (remove func()) {
+	return createHooks.add(hook)
+}
+
+// OnWrap registers a hook called whenever an error wraps a cause. The returned function removes the hook.
+func OnWrap(hook func(err Error, cause error)) (remove func()) {
+	return wrapHooks.add(hook)
+}
+
+// OnCapture registers a hook called before the location of a new error is captured,
+// capture is skipped if any hook returns false. The returned function removes the hook.
+func OnCapture(hook func(Error) bool) (remove func()) {
+	return captureHooks.add(hook)
+}
+
+// captures reports whether capture hooks allow to capture the location of err.
+func captures(err *implementation) bool {
+	for _, h := range captureHooks.load() {
+		if !h.fn(err) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// created is called for every error made by the package constructors.
+func created(err *implementation) {
+	countOn(MetricsOnCreate, err)
+
+	for _, h := range createHooks.load() {
+		h.fn(err)
+	}
+}
+
+func wrapped(err *implementation, cause error) {
+	for _, h := range wrapHooks.load() {
+		h.fn(err, cause)
+	}
+}
+
+type hook[T any] struct {
+	id uint64
+	fn T
+}
+
+// hookList is a copy-on-write list of hooks, loading it is a single atomic read.
+type hookList[T any] struct {
+	mu     sync.Mutex
+	nextId uint64
+	hooks  atomic.Pointer[[]hook[T]]
+}
+
+func (self *hookList[T]) add(fn T) func() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.nextId++
+
+	var (
+		id    = self.nextId
+		hooks = append(slices.Clone(self.load()), hook[T]{id: id, fn: fn})
+	)
+
+	self.hooks.Store(&hooks)
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() { self.remove(id) })
+	}
+}
+
+func (self *hookList[T]) remove(id uint64) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	var hooks = slices.DeleteFunc(slices.Clone(self.load()), func(h hook[T]) bool {
+		return h.id == id
+	})
+
+	if len(hooks) == 0 {
+		self.hooks.Store(nil)
+		return
+	}
+
+	self.hooks.Store(&hooks)
+}
+
+func (self *hookList[T]) load() []hook[T] {
+	if hooks := self.hooks.Load(); hooks != nil {
+		return *hooks
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package errors
+
+import (
+	"errors"
+	"sync"
+)
+
+func (suite *ErrorsSuite) TestOnCreate() {
+	var created []Error
+
+	var remove = OnCreate(func(err Error) {
+		created = append(created, err)
+		err.WithDetails(map[string]string{"version": "1.0.0"})
+	})
+
+	var (
+		first  = NewTimeoutError("timed out")
+		second = NewNotFoundFactory("not found").New()
+		third  = From(errors.New("kek"))
+	)
+
+	suite.Require().Equal([]Error{first, second, third}, created)
+	suite.Require().Equal(map[string]string{"version": "1.0.0"}, second.Details())
+
+	remove()
+	remove()
+
+	_ = NewTimeoutError("timed out")
+	suite.Require().Len(created, 3)
+}
+
+func (suite *ErrorsSuite) TestOnWrap() {
+	var (
+		parent = NewTimeoutError("timed out")
+		cause  = errors.New("kek")
+		calls  int
+	)
+
+	defer OnWrap(func(err Error, c error) {
+		calls++
+		suite.Require().Equal(parent, err)
+		suite.Require().Equal(cause, c)
+	})()
+
+	parent.Wrap(cause)
+	suite.Require().Equal(1, calls)
+}
+
+func (suite *ErrorsSuite) TestOnCapture() {
+	suite.requireStacks()
+
+	var seen []string
+
+	var remove = OnCapture(func(err Error) bool {
+		seen = append(seen, err.(Stacker).Location())
+		return KindOf(err) != ErrKindValidation
+	})
+
+	SetKindStackPolicy(ErrKindValidation, StackFull)
+	defer SetKindStackPolicy(ErrKindValidation, StackDefault)
+
+	var vetoed = NewValidationError("invalid").(*implementation)
+	suite.Require().Empty(vetoed.Location())
+	suite.Require().Empty(vetoed.stack)
+	suite.Require().Equal(errorId("invalid"), vetoed.id)
+	suite.Require().Contains(NewNotFoundError("not found").(Stacker).Location(), "hooks_test.go")
+
+	// hooks are called before the capture
+	suite.Require().Equal([]string{"", ""}, seen)
+
+	remove()
+	suite.Require().Contains(NewValidationError("invalid").(Stacker).Location(), "hooks_test.go")
+}
+
+func (suite *ErrorsSuite) TestHooksConcurrency() {
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Go(func() {
+			var remove = OnCreate(func(Error) {})
+			_ = NewTimeoutError("timed out")
+			remove()
+		})
+	}
+
+	wg.Wait()
+	suite.Require().Empty(createHooks.load())
+}
@@ -0,0 +1,148 @@
+// Package jsonrpcerrors renders errors as JSON-RPC 2.0 error objects and rebuilds them back.
+// Only user-friendly messages are exposed, kind, code, template id, labels and details go to data.
+package jsonrpcerrors
+
+import (
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/aerario/errors"
+)
+
+// Codes reserved by the JSON-RPC 2.0 specification.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// KindToCode maps kinds to JSON-RPC codes, unknown kinds are mapped to CodeInternalError.
+// Kinds with no reserved code use the range reserved for implementation-defined server errors.
+var KindToCode = map[errors.Kind]int{
+	errors.ErrKindGeneral:        CodeInternalError,
+	errors.ErrKindBadRequest:     CodeInvalidRequest,
+	errors.ErrKindValidation:     CodeInvalidParams,
+	errors.ErrKindAuthentication: -32001,
+	errors.ErrKindAuthorization:  -32002,
+	errors.ErrKindNotFound:       -32003,
+	errors.ErrKindAlreadyExists:  -32004,
+	errors.ErrKindLimitExceeded:  -32005,
+	errors.ErrKindInconsistent:   -32006,
+	errors.ErrKindPersistence:    -32007,
+	errors.ErrKindInfrastructure: -32008,
+	errors.ErrKindThirdParties:   -32009,
+	errors.ErrKindTimeout:        -32010,
+}
+
+// Object is a JSON-RPC 2.0 error object.
+type Object struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    *Data  `json:"data,omitempty"`
+}
+
+type Data struct {
+	Kind    string            `json:"kind"`
+	Code    string            `json:"code"`
+	Id      string            `json:"id,omitempty"` // template id, see errors.Inject
+	Labels  []string          `json:"labels,omitempty"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// New renders the error as an error object.
+func New(err error) Object {
+	var (
+		e       = errors.From(err)
+		kind    = errors.KindOf(e)
+		carrier = errors.MapCarrier{}
+	)
+
+	errors.Inject(e, carrier)
+
+	var data = &Data{
+		Kind:    kind.String(),
+		Code:    errors.Code(e),
+		Id:      carrier[errors.CarrierKeyId],
+		Details: e.Details(),
+	}
+
+	for _, l := range e.Labels() {
+		data.Labels = append(data.Labels, string(l))
+	}
+
+	var code, ok = KindToCode[kind]
+	if !ok {
+		code = CodeInternalError
+	}
+
+	return Object{
+		Code:    code,
+		Message: e.Error(),
+		Data:    data,
+	}
+}
+
+// Decode rebuilds an error out of an error object. Kind is taken from data if present,
+// otherwise it is looked up in KindToCode, parse and method errors are considered BadRequest.
+// Errors of other services carry no data, their messages are meant for clients, so they are user-friendly.
+func Decode(in Object) errors.Error {
+	var (
+		data = in.Data
+		kind = ToKind(in.Code)
+	)
+
+	if data == nil {
+		data = &Data{Labels: []string{string(errors.LabelUserFriendly)}}
+	} else if data.Kind != "" {
+		kind = errors.ParseKind(data.Kind)
+	}
+
+	// kinds with no name are only known to overrides of KindToCode, they cannot be rebuilt
+	if kind.String() == "" {
+		kind = errors.ErrKindGeneral
+	}
+
+	var carrier = errors.MapCarrier{
+		errors.CarrierKeyKind:    kind.String(),
+		errors.CarrierKeyId:      data.Id,
+		errors.CarrierKeyMessage: url.QueryEscape(in.Message),
+	}
+
+	if data.Code != "" && data.Code != kind.String() {
+		carrier[errors.CarrierKeyCode] = url.QueryEscape(data.Code)
+	}
+
+	var labels = make([]string, 0, len(data.Labels))
+	for _, l := range data.Labels {
+		labels = append(labels, url.QueryEscape(l))
+	}
+
+	carrier[errors.CarrierKeyLabels] = strings.Join(labels, ",")
+
+	var err = errors.Extract(carrier)
+	if len(data.Details) > 0 {
+		err = err.WithDetails(data.Details)
+	}
+
+	return err
+}
+
+// ToKind looks a kind up in KindToCode, if several kinds share the code the lowest of them wins.
+func ToKind(code int) errors.Kind {
+	switch code {
+	case CodeParseError, CodeMethodNotFound:
+		return errors.ErrKindBadRequest
+	}
+
+	for _, kind := range slices.Sorted(maps.Keys(KindToCode)) {
+		if KindToCode[kind] == code {
+			return kind
+		}
+	}
+
+	return errors.ErrKindGeneral
+}
@@ -0,0 +1,97 @@
+package jsonrpcerrors
+
+import (
+	"encoding/json/v2"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/aerario/errors"
+)
+
+type JSONRPCErrorsSuite struct {
+	suite.Suite
+}
+
+func TestJSONRPCErrorsSuite(t *testing.T) {
+	suite.Run(t, new(JSONRPCErrorsSuite))
+}
+
+func (suite *JSONRPCErrorsSuite) TestNew() {
+	var err = errors.WithCode(errors.NewValidationFactory("invalid email"), "EMAIL").New().
+		WithDetails(map[string]string{"field": "email"})
+
+	var object = New(err)
+	suite.Require().NotEmpty(object.Data.Id)
+
+	var data, e = json.Marshal(object)
+	suite.Require().NoError(e)
+	suite.Require().JSONEq(`{
+		"code": -32602,
+		"message": "invalid email",
+		"data": {
+			"kind": "Validation",
+			"code": "EMAIL",
+			"id": "`+object.Data.Id+`",
+			"labels": ["user-friendly"],
+			"details": {"field": "email"}
+		}
+	}`, string(data))
+
+	var decoded Object
+	suite.Require().NoError(json.Unmarshal(data, &decoded))
+
+	var rebuilt = Decode(decoded)
+	suite.Require().Equal(errors.ErrKindValidation, errors.KindOf(rebuilt))
+	suite.Require().Equal("EMAIL", errors.Code(rebuilt))
+	suite.Require().Equal("invalid email", rebuilt.Error())
+	suite.Require().Equal(map[string]string{"field": "email"}, rebuilt.Details())
+	suite.Require().True(errors.Is(rebuilt, errors.NewValidationFactory("invalid email")))
+	suite.Require().False(errors.Is(rebuilt, errors.NewValidationFactory("%s")))
+	suite.Require().False(errors.Is(rebuilt, Decode(New(errors.NewValidationError("invalid phone")))))
+}
+
+func (suite *JSONRPCErrorsSuite) TestNotUserFriendly() {
+	var object = New(errors.NewInfrastructureError("dial tcp: connection refused"))
+	suite.Require().Equal(errors.DefaultUserFriendlyError, object.Message)
+
+	var rebuilt = Decode(object)
+	suite.Require().Equal(errors.ErrKindInfrastructure, errors.KindOf(rebuilt))
+	suite.Require().False(errors.IsUserFriendly(rebuilt))
+
+	rebuilt = Decode(Object{Code: -32003, Message: "user not found"})
+	suite.Require().Equal(errors.ErrKindNotFound, errors.KindOf(rebuilt))
+	suite.Require().Equal("user not found", rebuilt.Error())
+}
+
+func (suite *JSONRPCErrorsSuite) TestSharedCode() {
+	KindToCode[errors.ErrKindThirdParties] = KindToCode[errors.ErrKindInfrastructure]
+	defer func() { KindToCode[errors.ErrKindThirdParties] = -32009 }()
+
+	for range 100 {
+		suite.Require().Equal(errors.ErrKindInfrastructure, ToKind(-32008))
+	}
+}
+
+func (suite *JSONRPCErrorsSuite) TestCodes() {
+	suite.Require().Equal(-32007, New(errors.NewPersistenceError("kek")).Code)
+	suite.Require().Equal(CodeInvalidRequest, New(errors.NewBadRequestError("kek")).Code)
+	suite.Require().Equal(CodeInternalError, New(errors.New(errors.Kind(100500), "kek")).Code)
+
+	var tests = []struct {
+		code int
+		kind errors.Kind
+	}{
+		{code: CodeParseError, kind: errors.ErrKindBadRequest},
+		{code: CodeMethodNotFound, kind: errors.ErrKindBadRequest},
+		{code: CodeInvalidRequest, kind: errors.ErrKindBadRequest},
+		{code: CodeInvalidParams, kind: errors.ErrKindValidation},
+		{code: CodeInternalError, kind: errors.ErrKindGeneral},
+		{code: -32003, kind: errors.ErrKindNotFound},
+		{code: 42, kind: errors.ErrKindGeneral},
+	}
+
+	for _, t := range tests {
+		suite.Require().Equal(t.kind, errors.KindOf(Decode(Object{Code: t.code, Message: "kek"})))
+	}
+}
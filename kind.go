@@ -18,44 +18,53 @@ const (
 	ErrKindTimeout
 )
 
+var kindNames = [...]string{
+	ErrKindGeneral:        "General",
+	ErrKindAuthentication: "Authentication",
+	ErrKindAuthorization:  "Authorization",
+	ErrKindBadRequest:     "BadRequest",
+	ErrKindValidation:     "Validation",
+	ErrKindNotFound:       "NotFound",
+	ErrKindAlreadyExists:  "AlreadyExists",
+	ErrKindLimitExceeded:  "LimitExceeded",
+	ErrKindInconsistent:   "Inconsistent",
+	ErrKindPersistence:    "Persistence",
+	ErrKindInfrastructure: "Infrastructure",
+	ErrKindThirdParties:   "ThirdParties",
+	ErrKindTimeout:        "Timeout",
+}
+
+var kindsByName = map[string]Kind{
+	"General":        ErrKindGeneral,
+	"Authentication": ErrKindAuthentication,
+	"Authorization":  ErrKindAuthorization,
+	"BadRequest":     ErrKindBadRequest,
+	"Validation":     ErrKindValidation,
+	"NotFound":       ErrKindNotFound,
+	"AlreadyExists":  ErrKindAlreadyExists,
+	"LimitExceeded":  ErrKindLimitExceeded,
+	"Inconsistent":   ErrKindInconsistent,
+	"Persistence":    ErrKindPersistence,
+	"Infrastructure": ErrKindInfrastructure,
+	"ThirdParties":   ErrKindThirdParties,
+	"Timeout":        ErrKindTimeout,
+}
+
 func kindName(k Kind) string {
-	var m = map[Kind]string{
-		ErrKindGeneral:        "General",
-		ErrKindAuthentication: "Authentication",
-		ErrKindAuthorization:  "Authorization",
-		ErrKindBadRequest:     "BadRequest",
-		ErrKindValidation:     "Validation",
-		ErrKindNotFound:       "NotFound",
-		ErrKindAlreadyExists:  "AlreadyExists",
-		ErrKindLimitExceeded:  "LimitExceeded",
-		ErrKindInconsistent:   "Inconsistent",
-		ErrKindPersistence:    "Persistence",
-		ErrKindInfrastructure: "Infrastructure",
-		ErrKindThirdParties:   "ThirdParties",
-		ErrKindTimeout:        "Timeout",
+	if k < Kind(len(kindNames)) {
+		return kindNames[k]
 	}
 
-	return m[k]
+	return ""
 }
 
-func ParseKind(code string) Kind {
-	var m = map[string]Kind{
-		"General":        ErrKindGeneral,
-		"Authentication": ErrKindAuthentication,
-		"Authorization":  ErrKindAuthorization,
-		"BadRequest":     ErrKindBadRequest,
-		"Validation":     ErrKindValidation,
-		"NotFound":       ErrKindNotFound,
-		"AlreadyExists":  ErrKindAlreadyExists,
-		"LimitExceeded":  ErrKindLimitExceeded,
-		"Inconsistent":   ErrKindInconsistent,
-		"Persistence":    ErrKindPersistence,
-		"Infrastructure": ErrKindInfrastructure,
-		"ThirdParties":   ErrKindThirdParties,
-		"Timeout":        ErrKindTimeout,
-	}
+// String returns the kind name, the same ParseKind accepts.
+func (k Kind) String() string {
+	return kindName(k)
+}
 
-	if kind, ok := m[code]; ok {
+func ParseKind(code string) Kind {
+	if kind, ok := kindsByName[code]; ok {
 		return kind
 	}
 
@@ -64,7 +73,7 @@ func ParseKind(code string) Kind {
 
 // NewAuthenticationError returns an Authentication error.
 func NewAuthenticationError(message string, args ...interface{}) Error {
-	return New(ErrKindAuthentication, message, args...)
+	return NewSkip(1, ErrKindAuthentication, message, args...)
 }
 
 // NewAuthenticationFactory returns an error factory that creates Authentication user-friendly errors.
@@ -74,7 +83,7 @@ func NewAuthenticationFactory(template string) Factory {
 
 // NewAuthorizationError returns an Authorization error.
 func NewAuthorizationError(message string, args ...interface{}) Error {
-	return New(ErrKindAuthorization, message, args...)
+	return NewSkip(1, ErrKindAuthorization, message, args...)
 }
 
 // NewAuthorizationFactory returns an error factory that creates Authorization user-friendly errors.
@@ -84,7 +93,7 @@ func NewAuthorizationFactory(template string) Factory {
 
 // NewBadRequestError returns an BadRequest error.
 func NewBadRequestError(message string, args ...interface{}) Error {
-	return New(ErrKindBadRequest, message, args...)
+	return NewSkip(1, ErrKindBadRequest, message, args...)
 }
 
 // NewBadRequestFactory returns an error factory that creates BadRequest user-friendly errors.
@@ -94,7 +103,7 @@ func NewBadRequestFactory(template string) Factory {
 
 // NewValidationError returns an Validation error.
 func NewValidationError(message string, args ...interface{}) Error {
-	return New(ErrKindValidation, message, args...)
+	return NewSkip(1, ErrKindValidation, message, args...)
 }
 
 // NewValidationFactory returns an error factory that creates Validation user-friendly errors.
@@ -104,7 +113,7 @@ func NewValidationFactory(template string) Factory {
 
 // NewNotFoundError returns an NotFound error.
 func NewNotFoundError(message string, args ...interface{}) Error {
-	return New(ErrKindNotFound, message, args...)
+	return NewSkip(1, ErrKindNotFound, message, args...)
 }
 
 // NewNotFoundFactory returns an error factory that creates NotFound user-friendly errors.
@@ -114,7 +123,7 @@ func NewNotFoundFactory(template string) Factory {
 
 // NewAlreadyExistsError returns an AlreadyExists error.
 func NewAlreadyExistsError(message string, args ...interface{}) Error {
-	return New(ErrKindAlreadyExists, message, args...)
+	return NewSkip(1, ErrKindAlreadyExists, message, args...)
 }
 
 // NewAlreadyExistsFactory returns an error factory that creates AlreadyExists user-friendly errors.
@@ -124,7 +133,7 @@ func NewAlreadyExistsFactory(template string) Factory {
 
 // NewLimitExceededError returns an LimitExceeded error.
 func NewLimitExceededError(message string, args ...interface{}) Error {
-	return New(ErrKindLimitExceeded, message, args...)
+	return NewSkip(1, ErrKindLimitExceeded, message, args...)
 }
 
 // NewLimitExceededFactory returns an error factory that creates LimitExceeded user-friendly errors.
@@ -134,7 +143,7 @@ func NewLimitExceededFactory(template string) Factory {
 
 // NewInconsistentError returns an Inconsistent error.
 func NewInconsistentError(message string, args ...interface{}) Error {
-	return New(ErrKindInconsistent, message, args...)
+	return NewSkip(1, ErrKindInconsistent, message, args...)
 }
 
 // NewInconsistentFactory returns an error factory that creates Inconsistent user-friendly errors.
@@ -144,7 +153,7 @@ func NewInconsistentFactory(template string) Factory {
 
 // NewPersistenceError returns an Persistence error.
 func NewPersistenceError(message string, args ...interface{}) Error {
-	return New(ErrKindPersistence, message, args...)
+	return NewSkip(1, ErrKindPersistence, message, args...)
 }
 
 // NewPersistenceFactory returns an error factory that creates Persistence user-friendly errors.
@@ -154,7 +163,7 @@ func NewPersistenceFactory(template string) Factory {
 
 // NewInfrastructureError returns an Infrastructure error.
 func NewInfrastructureError(message string, args ...interface{}) Error {
-	return New(ErrKindInfrastructure, message, args...)
+	return NewSkip(1, ErrKindInfrastructure, message, args...)
 }
 
 // NewInfrastructureFactory returns an error factory that creates Infrastructure user-friendly errors.
@@ -164,7 +173,7 @@ func NewInfrastructureFactory(template string) Factory {
 
 // NewThirdPartiesError returns an ThirdParties error.
 func NewThirdPartiesError(message string, args ...interface{}) Error {
-	return New(ErrKindThirdParties, message, args...)
+	return NewSkip(1, ErrKindThirdParties, message, args...)
 }
 
 // NewThirdPartiesFactory returns an error factory that creates ThirdParties user-friendly errors.
@@ -174,7 +183,7 @@ func NewThirdPartiesFactory(template string) Factory {
 
 // NewTimeoutError returns an Timeout error.
 func NewTimeoutError(message string, args ...interface{}) Error {
-	return New(ErrKindTimeout, message, args...)
+	return NewSkip(1, ErrKindTimeout, message, args...)
 }
 
 // NewTimeoutFactory returns an error factory that creates Timeout user-friendly errors.
@@ -23,6 +23,8 @@ func (suite *ErrorKindSuite) TestAuthenticationError() {
 	suite.Require().Equal(err.Error(), DefaultUserFriendlyError)
 	suite.Require().Equal(t.String(), "something Authentication")
 	suite.Require().Equal(ErrKindAuthentication, KindOf(err))
+	suite.Require().Equal("Authentication", KindOf(err).String())
+	suite.Require().Equal(ErrKindAuthentication, ParseKind(KindOf(err).String()))
 }
 
 func (suite *ErrorKindSuite) TestAuthenticationFactory() {
@@ -49,6 +51,8 @@ func (suite *ErrorKindSuite) TestAuthorizationError() {
 	suite.Require().Equal(err.Error(), DefaultUserFriendlyError)
 	suite.Require().Equal(t.String(), "something Authorization")
 	suite.Require().Equal(ErrKindAuthorization, KindOf(err))
+	suite.Require().Equal("Authorization", KindOf(err).String())
+	suite.Require().Equal(ErrKindAuthorization, ParseKind(KindOf(err).String()))
 }
 
 func (suite *ErrorKindSuite) TestAuthorizationFactory() {
@@ -75,6 +79,8 @@ func (suite *ErrorKindSuite) TestBadRequestError() {
 	suite.Require().Equal(err.Error(), DefaultUserFriendlyError)
 	suite.Require().Equal(t.String(), "something BadRequest")
 	suite.Require().Equal(ErrKindBadRequest, KindOf(err))
+	suite.Require().Equal("BadRequest", KindOf(err).String())
+	suite.Require().Equal(ErrKindBadRequest, ParseKind(KindOf(err).String()))
 }
 
 func (suite *ErrorKindSuite) TestBadRequestFactory() {
@@ -101,6 +107,8 @@ func (suite *ErrorKindSuite) TestValidationError() {
 	suite.Require().Equal(err.Error(), DefaultUserFriendlyError)
 	suite.Require().Equal(t.String(), "something Validation")
 	suite.Require().Equal(ErrKindValidation, KindOf(err))
+	suite.Require().Equal("Validation", KindOf(err).String())
+	suite.Require().Equal(ErrKindValidation, ParseKind(KindOf(err).String()))
 }
 
 func (suite *ErrorKindSuite) TestValidationFactory() {
@@ -127,6 +135,8 @@ func (suite *ErrorKindSuite) TestNotFoundError() {
 	suite.Require().Equal(err.Error(), DefaultUserFriendlyError)
 	suite.Require().Equal(t.String(), "something NotFound")
 	suite.Require().Equal(ErrKindNotFound, KindOf(err))
+	suite.Require().Equal("NotFound", KindOf(err).String())
+	suite.Require().Equal(ErrKindNotFound, ParseKind(KindOf(err).String()))
 }
 
 func (suite *ErrorKindSuite) TestNotFoundFactory() {
@@ -153,6 +163,8 @@ func (suite *ErrorKindSuite) TestAlreadyExistsError() {
 	suite.Require().Equal(err.Error(), DefaultUserFriendlyError)
 	suite.Require().Equal(t.String(), "something AlreadyExists")
 	suite.Require().Equal(ErrKindAlreadyExists, KindOf(err))
+	suite.Require().Equal("AlreadyExists", KindOf(err).String())
+	suite.Require().Equal(ErrKindAlreadyExists, ParseKind(KindOf(err).String()))
 }
 
 func (suite *ErrorKindSuite) TestAlreadyExistsFactory() {
@@ -179,6 +191,8 @@ func (suite *ErrorKindSuite) TestLimitExceededError() {
 	suite.Require().Equal(err.Error(), DefaultUserFriendlyError)
 	suite.Require().Equal(t.String(), "something LimitExceeded")
 	suite.Require().Equal(ErrKindLimitExceeded, KindOf(err))
+	suite.Require().Equal("LimitExceeded", KindOf(err).String())
+	suite.Require().Equal(ErrKindLimitExceeded, ParseKind(KindOf(err).String()))
 }
 
 func (suite *ErrorKindSuite) TestLimitExceededFactory() {
@@ -205,6 +219,8 @@ func (suite *ErrorKindSuite) TestInconsistentError() {
 	suite.Require().Equal(err.Error(), DefaultUserFriendlyError)
 	suite.Require().Equal(t.String(), "something Inconsistent")
 	suite.Require().Equal(ErrKindInconsistent, KindOf(err))
+	suite.Require().Equal("Inconsistent", KindOf(err).String())
+	suite.Require().Equal(ErrKindInconsistent, ParseKind(KindOf(err).String()))
 }
 
 func (suite *ErrorKindSuite) TestInconsistentFactory() {
@@ -231,6 +247,8 @@ func (suite *ErrorKindSuite) TestPersistenceError() {
 	suite.Require().Equal(err.Error(), DefaultUserFriendlyError)
 	suite.Require().Equal(t.String(), "something Persistence")
 	suite.Require().Equal(ErrKindPersistence, KindOf(err))
+	suite.Require().Equal("Persistence", KindOf(err).String())
+	suite.Require().Equal(ErrKindPersistence, ParseKind(KindOf(err).String()))
 }
 
 func (suite *ErrorKindSuite) TestPersistenceFactory() {
@@ -257,6 +275,8 @@ func (suite *ErrorKindSuite) TestInfrastructureError() {
 	suite.Require().Equal(err.Error(), DefaultUserFriendlyError)
 	suite.Require().Equal(t.String(), "something Infrastructure")
 	suite.Require().Equal(ErrKindInfrastructure, KindOf(err))
+	suite.Require().Equal("Infrastructure", KindOf(err).String())
+	suite.Require().Equal(ErrKindInfrastructure, ParseKind(KindOf(err).String()))
 }
 
 func (suite *ErrorKindSuite) TestInfrastructureFactory() {
@@ -283,6 +303,8 @@ func (suite *ErrorKindSuite) TestThirdPartiesError() {
 	suite.Require().Equal(err.Error(), DefaultUserFriendlyError)
 	suite.Require().Equal(t.String(), "something ThirdParties")
 	suite.Require().Equal(ErrKindThirdParties, KindOf(err))
+	suite.Require().Equal("ThirdParties", KindOf(err).String())
+	suite.Require().Equal(ErrKindThirdParties, ParseKind(KindOf(err).String()))
 }
 
 func (suite *ErrorKindSuite) TestThirdPartiesFactory() {
@@ -309,6 +331,8 @@ func (suite *ErrorKindSuite) TestTimeoutError() {
 	suite.Require().Equal(err.Error(), DefaultUserFriendlyError)
 	suite.Require().Equal(t.String(), "something Timeout")
 	suite.Require().Equal(ErrKindTimeout, KindOf(err))
+	suite.Require().Equal("Timeout", KindOf(err).String())
+	suite.Require().Equal(ErrKindTimeout, ParseKind(KindOf(err).String()))
 }
 
 func (suite *ErrorKindSuite) TestTimeoutFactory() {
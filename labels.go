@@ -2,6 +2,9 @@ package errors
 
 const (
 	LabelUserFriendly Label = "user-friendly"
+	LabelPanic        Label = "panic"   // error is made out of a recovered panic
+	LabelRuntime      Label = "runtime" // error is made out of a recovered runtime.Error panic
+	LabelRetryable    Label = "retryable"
 )
 
 type Label string
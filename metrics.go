@@ -0,0 +1,200 @@
+package errors
+
+import (
+	"expvar"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// MetricsMode defines which calls increment error counters.
+type MetricsMode int32
+
+const (
+	// MetricsOnCreate counts errors made by New, Factory.New and From.
+	MetricsOnCreate MetricsMode = iota
+	// MetricsOnReport counts errors passed to Report only.
+	MetricsOnReport
+	MetricsDisabled
+)
+
+const (
+	// MetricsCodeOther replaces codes over the limit, see SetMaxMetricCodes.
+	MetricsCodeOther = "other"
+	// DefaultMaxMetricCodes is the default limit of distinct codes counters are kept for.
+	DefaultMaxMetricCodes = 100
+)
+
+// MetricKey identifies an error counter.
+type MetricKey struct {
+	Kind    string
+	Code    string
+	Package string
+}
+
+func (self MetricKey) String() string {
+	return fmt.Sprintf("%s;%s;%s", self.Kind, self.Code, self.Package)
+}
+
+// Meter receives error counts, implement it to export counters to Prometheus, OpenTelemetry etc.
+type Meter interface {
+	Count(key MetricKey)
+}
+
+var (
+	metricsMode    atomic.Int32
+	meter          atomic.Pointer[Meter]
+	metrics        atomic.Pointer[metricsState]
+	maxMetricCodes atomic.Int64
+)
+
+// metricsState holds counters along with their caches, ResetMetrics replaces all of them at once,
+// so that no cache refers to a dropped counter.
+type metricsState struct {
+	counters sync.Map // MetricKey -> *atomic.Int64
+	sites    pcCache[counterSite]
+
+	codesMu    sync.Mutex
+	codes      sync.Map // code -> struct{}
+	codesCount int
+}
+
+func init() {
+	metrics.Store(new(metricsState))
+	maxMetricCodes.Store(DefaultMaxMetricCodes)
+}
+
+// PublishMetrics publishes counters as an expvar variable under the name, e.g. "errors".
+// Nothing is published if the name is taken, false is returned then.
+func PublishMetrics(name string) bool {
+	if expvar.Get(name) != nil {
+		return false
+	}
+
+	expvar.Publish(name, expvar.Func(func() any {
+		var out = make(map[string]int64)
+		for k, v := range Metrics() {
+			out[k.String()] = v
+		}
+
+		return out
+	}))
+
+	return true
+}
+
+// SetMetricsMode sets which calls increment error counters.
+func SetMetricsMode(mode MetricsMode) {
+	metricsMode.Store(int32(mode))
+}
+
+// SetMaxMetricCodes limits the number of distinct codes counters are kept for,
+// codes over the limit are counted as MetricsCodeOther. Codes already counted are kept.
+func SetMaxMetricCodes(n int) {
+	maxMetricCodes.Store(int64(n))
+}
+
+// SetMeter sets a meter which receives error counts in addition to built-in counters, nil removes it.
+func SetMeter(m Meter) {
+	if m == nil {
+		meter.Store(nil)
+		return
+	}
+
+	meter.Store(&m)
+}
+
+// Metrics returns a snapshot of error counters.
+func Metrics() map[MetricKey]int64 {
+	var out = make(map[MetricKey]int64)
+
+	metrics.Load().counters.Range(func(k, v any) bool {
+		out[k.(MetricKey)] = v.(*atomic.Int64).Load()
+		return true
+	})
+
+	return out
+}
+
+// ResetMetrics drops all the counters.
+func ResetMetrics() {
+	metrics.Store(new(metricsState))
+}
+
+func countOn(mode MetricsMode, err error) {
+	if MetricsMode(metricsMode.Load()) != mode {
+		return
+	}
+
+	var t, ok = err.(*implementation)
+	if !ok {
+		return
+	}
+
+	var site = metrics.Load().siteOf(t)
+	site.counter.Add(1)
+
+	if m := meter.Load(); m != nil {
+		(*m).Count(site.key)
+	}
+}
+
+// counterSite is a counter of errors created at a call site.
+type counterSite struct {
+	kind    Kind
+	code    string
+	key     MetricKey
+	counter *atomic.Int64
+}
+
+// siteOf returns the counter of the error, counters are cached per call site to avoid allocations.
+func (self *metricsState) siteOf(err *implementation) counterSite {
+	// errors without locations share a slot per kind, program counters are never that small
+	var slot = err.location.pc
+	if slot == 0 {
+		slot = uintptr(err.kind) + 1
+	}
+
+	if site, ok := self.sites.load(slot); ok && site.kind == err.kind && site.code == err.code {
+		return site
+	}
+
+	var site = counterSite{
+		kind: err.kind,
+		code: err.code,
+		key: MetricKey{
+			Kind:    kindName(err.kind),
+			Code:    self.metricCode(err.ErrorCode()),
+			Package: err.location.pkg(),
+		},
+	}
+
+	var counter, _ = self.counters.LoadOrStore(site.key, new(atomic.Int64))
+	site.counter = counter.(*atomic.Int64)
+	self.sites.store(slot, site)
+
+	return site
+}
+
+// metricCode keeps the number of distinct codes under the limit, see SetMaxMetricCodes.
+func (self *metricsState) metricCode(code string) string {
+	if _, ok := self.codes.Load(code); ok {
+		return code
+	}
+
+	self.codesMu.Lock()
+	defer self.codesMu.Unlock()
+
+	if _, ok := self.codes.Load(code); ok {
+		return code
+	}
+
+	if int64(self.codesCount) >= maxMetricCodes.Load() {
+		return MetricsCodeOther
+	}
+
+	self.codes.Store(code, struct{}{})
+	self.codesCount++
+
+	return code
+}
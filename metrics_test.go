@@ -0,0 +1,99 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"sync"
+)
+
+type meterMock []MetricKey
+
+func (self *meterMock) Count(key MetricKey) {
+	*self = append(*self, key)
+}
+
+func (suite *ErrorsSuite) TestMetrics() {
+	var m meterMock
+
+	ResetMetrics()
+	SetMeter(&m)
+	defer SetMeter(nil)
+
+	var fac = WithCode(NewNotFoundFactory("user %d not found"), "USER-404")
+	_ = fac.New(1)
+	_ = fac.New(2)
+	_ = NewTimeoutError("timed out")
+	_ = From(errors.New("kek"))
+
+	var pkg = testPackage()
+	suite.Require().Equal(map[MetricKey]int64{
+		{Kind: "NotFound", Code: "USER-404", Package: pkg}: 2,
+		{Kind: "Timeout", Code: "Timeout", Package: pkg}:   1,
+		{Kind: "General", Code: "General"}:                 1,
+	}, Metrics())
+	suite.Require().Len(m, 4)
+
+	PublishMetrics("errors_test")
+	suite.Require().False(PublishMetrics("errors_test"))
+	suite.Require().Contains(expvar.Get("errors_test").String(), `"NotFound;USER-404;`+pkg+`":2`)
+}
+
+func (suite *ErrorsSuite) TestMetricsOnReport() {
+	ResetMetrics()
+	SetMetricsMode(MetricsOnReport)
+	defer SetMetricsMode(MetricsOnCreate)
+
+	var err = NewTimeoutError("timed out")
+	suite.Require().Empty(Metrics())
+
+	Report(context.Background(), err)
+	suite.Require().Equal(map[MetricKey]int64{
+		{Kind: "Timeout", Code: "Timeout", Package: testPackage()}: 1,
+	}, Metrics())
+}
+
+func (suite *ErrorsSuite) TestMetricsCodesLimit() {
+	ResetMetrics()
+	SetMaxMetricCodes(2)
+	defer SetMaxMetricCodes(DefaultMaxMetricCodes)
+
+	for i := range 5 {
+		_ = WithCode(NewNotFoundFactory("not found"), fmt.Sprintf("CODE-%d", i)).New()
+	}
+
+	var metrics = Metrics()
+	suite.Require().Len(metrics, 3)
+	suite.Require().Equal(int64(3), metrics[MetricKey{Kind: "NotFound", Code: MetricsCodeOther, Package: testPackage()}])
+}
+
+func metricsCallSite() Error {
+	return NewTimeoutError("timed out")
+}
+
+func (suite *ErrorsSuite) TestResetMetricsConcurrency() {
+	var wg sync.WaitGroup
+
+	for range 4 {
+		wg.Go(func() {
+			for range 1000 {
+				_ = metricsCallSite()
+			}
+		})
+	}
+
+	for range 100 {
+		ResetMetrics()
+	}
+
+	wg.Wait()
+
+	// the call site is not cached with a counter dropped by a reset
+	ResetMetrics()
+	_ = metricsCallSite()
+
+	suite.Require().Equal(map[MetricKey]int64{
+		{Kind: "Timeout", Code: "Timeout", Package: testPackage()}: 1,
+	}, Metrics())
+}
@@ -0,0 +1,140 @@
+package errors
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"os"
+	"reflect"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// SymbolAnchor is the name of the function which address is recorded along with program counters,
+// the difference between its address in the binary and the recorded one is the load offset of the executable.
+const SymbolAnchor = "github.com/aerario/errors.symbolAnchor"
+
+// goBuildIdNoteType is the type of ELF note holding the Go build id.
+const goBuildIdNoteType = 4
+
+var (
+	// ErrNoBuildId is returned by ReadBuildId for executables without the Go build id note.
+	ErrNoBuildId = NewNotFoundFactory("no Go build id in %s")
+	// ErrNotELF is returned by ReadBuildId for files other than ELF executables, e.g. Mach-O or PE ones.
+	ErrNotELF = NewValidationFactory("%s is not an ELF executable")
+)
+
+var offlineSymbolization atomic.Bool
+
+// SetOfflineSymbolization makes StackTrace carry raw program counters along with the build id and the module
+// version of the executable instead of locations and functions. It saves the cost of symbolization and keeps
+// paths of the build machine out of logs, cmd/errsymbolize restores locations with the matching binary.
+func SetOfflineSymbolization(enabled bool) {
+	offlineSymbolization.Store(enabled)
+}
+
+//go:noinline
+func symbolAnchor() {}
+
+type executableIdentity struct {
+	buildId string
+	module  string
+	anchor  string
+}
+
+// executable identifies the running binary, the build id is empty when it cannot be read.
+var executable = sync.OnceValue(func() executableIdentity {
+	var out = executableIdentity{
+		anchor: formatPC(reflect.ValueOf(symbolAnchor).Pointer()),
+	}
+
+	if path, err := os.Executable(); err == nil {
+		out.buildId, _ = ReadBuildId(path)
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		out.module = info.Main.Path + "@" + info.Main.Version
+	}
+
+	return out
+})
+
+// setPCs fills the frame with raw program counters of the error.
+func (self *stackTraceFrame) setPCs(err *implementation) {
+	switch {
+	case err.location.pc != 0:
+		self.PC = formatPC(err.location.pc)
+	case err.location.file != "":
+		self.Location = err.location.String()
+	}
+
+	for _, pc := range err.stack {
+		self.PCs = append(self.PCs, formatPC(pc))
+	}
+
+	if self.PC != "" || len(self.PCs) > 0 {
+		var id = executable()
+		self.BuildId, self.Module, self.Anchor = id.buildId, id.module, id.anchor
+	}
+}
+
+func formatPC(pc uintptr) string {
+	return "0x" + strconv.FormatUint(uint64(pc), 16)
+}
+
+// ReadBuildId reads the Go build id of an ELF executable, other formats are not supported.
+func ReadBuildId(path string) (string, error) {
+	var f, err = openELF(path)
+	if err != nil {
+		return "", err
+	}
+
+	defer f.Close()
+
+	var section = f.Section(".note.go.buildid")
+	if section == nil {
+		return "", ErrNoBuildId.New(path)
+	}
+
+	var data []byte
+	if data, err = section.Data(); err != nil {
+		return "", err
+	}
+
+	return parseBuildIdNote(data, f.ByteOrder)
+}
+
+// openELF opens an ELF executable, files of other formats are reported as ErrNotELF.
+func openELF(path string) (*elf.File, error) {
+	var f, err = elf.Open(path)
+
+	var format *elf.FormatError
+	if As(err, &format) {
+		return nil, ErrNotELF.New(path).Wrap(err)
+	}
+
+	return f, err
+}
+
+// parseBuildIdNote parses an ELF note: name size, description size, type, then padded name and description.
+func parseBuildIdNote(data []byte, order binary.ByteOrder) (string, error) {
+	if len(data) < 16 {
+		return "", ErrNoBuildId.New("the note")
+	}
+
+	var (
+		nameSize = int(order.Uint32(data[0:]))
+		descSize = int(order.Uint32(data[4:]))
+		noteType = order.Uint32(data[8:])
+		name     = 12
+		desc     = name + (nameSize+3)&^3
+	)
+
+	if noteType != goBuildIdNoteType || desc+descSize > len(data) || string(bytes.TrimRight(data[name:name+nameSize], "\x00")) != "Go" {
+		return "", ErrNoBuildId.New("the note")
+	}
+
+	return string(data[desc : desc+descSize]), nil
+}
@@ -0,0 +1,80 @@
+package errors
+
+import (
+	"encoding/binary"
+	"encoding/json/v2"
+	"os"
+	"path/filepath"
+)
+
+func (suite *ErrorsSuite) TestOfflineSymbolization() {
+	suite.requireStacks()
+
+	SetOfflineSymbolization(true)
+	defer SetOfflineSymbolization(false)
+
+	var err = NewNotFoundError("kek").Wrap(&implementation{location: location{file: "kek.go", line: 42}}).(*implementation)
+
+	var frames []stackTraceFrame
+	suite.Require().NoError(json.Unmarshal(err.StackTrace(), &frames))
+	suite.Require().Len(frames, 2)
+
+	suite.Require().Equal(formatPC(err.location.pc), frames[0].PC)
+	suite.Require().Empty(frames[0].Location)
+	suite.Require().Empty(frames[0].Function)
+	suite.Require().Equal(executable().anchor, frames[0].Anchor)
+	suite.Require().Contains(frames[0].Module, "github.com/aerario/errors@")
+
+	// locations without program counters are kept
+	suite.Require().Equal("kek.go:42", frames[1].Location)
+	suite.Require().Empty(frames[1].PC)
+	suite.Require().Empty(frames[1].BuildId)
+
+	// the location is still available on demand
+	suite.Require().Regexp(`^offline_test\.go:\d+$`, err.Location())
+}
+
+func (suite *ErrorsSuite) TestReadBuildId() {
+	var path, err = os.Executable()
+	suite.Require().NoError(err)
+
+	var id string
+	id, err = ReadBuildId(path)
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(id)
+	suite.Require().Equal(id, executable().buildId)
+
+	_, err = ReadBuildId("/nowhere")
+	suite.Require().Error(err)
+	suite.Require().False(Is(err, ErrNotELF))
+
+	var script = filepath.Join(suite.T().TempDir(), "kek.sh")
+	suite.Require().NoError(os.WriteFile(script, []byte("#!/bin/sh\n"), 0o755))
+
+	_, err = ReadBuildId(script)
+	suite.Require().True(Is(err, ErrNotELF))
+
+	var note = func(noteType uint32, name, desc string) []byte {
+		var out = binary.LittleEndian.AppendUint32(nil, uint32(len(name)))
+		out = binary.LittleEndian.AppendUint32(out, uint32(len(desc)))
+		out = binary.LittleEndian.AppendUint32(out, noteType)
+		out = append(out, name...)
+		out = append(out, make([]byte, (4-len(name)%4)%4)...)
+
+		return append(out, desc...)
+	}
+
+	id, err = parseBuildIdNote(note(goBuildIdNoteType, "Go\x00\x00", "kek/lol"), binary.LittleEndian)
+	suite.Require().NoError(err)
+	suite.Require().Equal("kek/lol", id)
+
+	for _, data := range [][]byte{
+		nil,
+		note(3, "Go\x00\x00", "kek/lol"),
+		note(goBuildIdNoteType, "GNU\x00", "kek/lol"),
+		note(goBuildIdNoteType, "Go\x00\x00", "kek/lol")[:18],
+	} {
+		_, err = parseBuildIdNote(data, binary.LittleEndian)
+		suite.Require().True(Is(err, ErrNoBuildId))
+	}
+}
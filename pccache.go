@@ -0,0 +1,36 @@
+package errors
+
+import (
+	"sync/atomic"
+)
+
+// pcCacheBits defines the size of pcCache.
+const pcCacheBits = 10
+
+// pcCache is a lock-free direct-mapped cache of values computed for program counters.
+// It does not allocate on lookups, entries of colliding program counters replace each other.
+type pcCache[T any] [1 << pcCacheBits]atomic.Pointer[pcEntry[T]]
+
+type pcEntry[T any] struct {
+	pc    uintptr
+	value T
+}
+
+func (self *pcCache[T]) load(pc uintptr) (T, bool) {
+	if e := self[pcSlot(pc)].Load(); e != nil && e.pc == pc {
+		return e.value, true
+	}
+
+	var zero T
+
+	return zero, false
+}
+
+func (self *pcCache[T]) store(pc uintptr, value T) {
+	self[pcSlot(pc)].Store(&pcEntry[T]{pc: pc, value: value})
+}
+
+// pcSlot spreads program counters with Fibonacci hashing.
+func pcSlot(pc uintptr) uint64 {
+	return (uint64(pc) * 0x9E3779B97F4A7C15) >> (64 - pcCacheBits)
+}
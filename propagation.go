@@ -0,0 +1,254 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Carrier keys used by Inject and Extract.
+const (
+	CarrierKeyKind    = "x-error-kind"
+	CarrierKeyCode    = "x-error-code"
+	CarrierKeyId      = "x-error-id"
+	CarrierKeyMessage = "x-error-message"
+	CarrierKeyLabels  = "x-error-labels"
+	CarrierKeyDetails = "x-error-details"
+	CarrierKeyCauses  = "x-error-causes"
+)
+
+var (
+	// MaxCarrierValueSize limits the size of each injected value, details over the limit are dropped
+	// and the message is truncated.
+	MaxCarrierValueSize = 1024
+	// MaxCarrierCauses limits the number of causes injected.
+	MaxCarrierCauses = 8
+)
+
+// Carrier is a storage of string key-value pairs such as message queue or HTTP headers.
+// It mirrors OpenTelemetry TextMapCarrier, so the same adapters work for both.
+type Carrier interface {
+	Get(key string) string
+	Set(key, value string)
+	Keys() []string
+}
+
+// MapCarrier is a Carrier backed by a map.
+type MapCarrier map[string]string
+
+func (self MapCarrier) Get(key string) string {
+	return self[key]
+}
+
+func (self MapCarrier) Set(key, value string) {
+	self[key] = value
+}
+
+func (self MapCarrier) Keys() []string {
+	var out = make([]string, 0, len(self))
+	for k := range self {
+		out = append(out, k)
+	}
+
+	return out
+}
+
+// HeaderCarrier is a Carrier backed by HTTP headers.
+type HeaderCarrier http.Header
+
+func (self HeaderCarrier) Get(key string) string {
+	return http.Header(self).Get(key)
+}
+
+func (self HeaderCarrier) Set(key, value string) {
+	http.Header(self).Set(key, value)
+}
+
+func (self HeaderCarrier) Keys() []string {
+	var out = make([]string, 0, len(self))
+	for k := range self {
+		out = append(out, k)
+	}
+
+	return out
+}
+
+// Inject encodes the error into header-safe carrier values: kind, code, template id,
+// user-friendly message, labels, details and a compact chain of causes.
+func Inject(err error, carrier Carrier) {
+	if err == nil {
+		return
+	}
+
+	var t = From(err).(*implementation)
+
+	carrier.Set(CarrierKeyKind, kindName(t.kind))
+	carrier.Set(CarrierKeyId, strconv.FormatUint(uint64(t.id), 10))
+
+	if t.code != "" {
+		carrier.Set(CarrierKeyCode, url.QueryEscape(t.code))
+	}
+
+	if out := userFriendly(t); len(out) > 0 {
+		var message = url.QueryEscape(strings.Join(out, ": "))
+		if len(message) > MaxCarrierValueSize {
+			// escaping makes a value at most 3 times longer
+			message = url.QueryEscape(truncate(strings.Join(out, ": "), MaxCarrierValueSize/3))
+		}
+
+		carrier.Set(CarrierKeyMessage, message)
+	}
+
+	if len(t.labels) > 0 {
+		var labels = make([]string, 0, len(t.labels))
+		for _, l := range t.labels {
+			labels = append(labels, url.QueryEscape(string(l)))
+		}
+
+		carrier.Set(CarrierKeyLabels, limit(labels, ","))
+	}
+
+	if details := t.Details(); len(details) > 0 {
+		var values = make([]string, 0, len(details))
+		for k, v := range details {
+			values = append(values, url.QueryEscape(k)+"="+url.QueryEscape(v))
+		}
+
+		carrier.Set(CarrierKeyDetails, limit(values, "&"))
+	}
+
+	var causes []string
+	for cause := t.previous; cause != nil && len(causes) < MaxCarrierCauses; cause = errors.Unwrap(cause) {
+		if c, ok := cause.(*implementation); ok {
+			causes = append(causes, kindName(c.kind)+":"+url.QueryEscape(c.code)+":"+strconv.FormatUint(uint64(c.id), 10))
+		}
+	}
+
+	if len(causes) > 0 {
+		carrier.Set(CarrierKeyCauses, limit(causes, ","))
+	}
+}
+
+// Extract rebuilds an error injected into the carrier, nil is returned if there is no error.
+// The rebuilt error keeps kind, code and template id, so Is matches it against the original factory.
+// Errors with no id get an id of their message.
+func Extract(carrier Carrier) Error {
+	var kind = carrier.Get(CarrierKeyKind)
+	if kind == "" {
+		return nil
+	}
+
+	var err = &implementation{
+		id:   parseId(carrier.Get(CarrierKeyId)),
+		kind: ParseKind(kind),
+		code: unescape(carrier.Get(CarrierKeyCode)),
+	}
+
+	if labels := carrier.Get(CarrierKeyLabels); labels != "" {
+		for _, l := range strings.Split(labels, ",") {
+			err.labels = append(err.labels, Label(unescape(l)))
+		}
+	}
+
+	if err.message = unescape(carrier.Get(CarrierKeyMessage)); err.message == "" {
+		err.message = err.ErrorCode()
+		err.labels = removeLabel(err.labels, LabelUserFriendly)
+	}
+
+	// errors of other services carry no id, they are told apart by messages
+	if carrier.Get(CarrierKeyId) == "" {
+		err.id = errorId(err.message)
+	}
+
+	if details, e := url.ParseQuery(carrier.Get(CarrierKeyDetails)); e == nil && len(details) > 0 {
+		err.details = make(map[string]string, len(details))
+		for k, v := range details {
+			err.details[k] = v[0]
+		}
+	}
+
+	var last = err
+	if causes := carrier.Get(CarrierKeyCauses); causes != "" {
+		for _, c := range strings.Split(causes, ",") {
+			var parts = strings.SplitN(c, ":", 3)
+			if len(parts) != 3 {
+				break
+			}
+
+			var cause = &implementation{
+				id:   parseId(parts[2]),
+				kind: ParseKind(parts[0]),
+				code: unescape(parts[1]),
+			}
+
+			cause.message = cause.ErrorCode()
+			last.previous = cause
+			last = cause
+		}
+	}
+
+	created(err)
+
+	return err
+}
+
+// limit joins values until the result fits MaxCarrierValueSize.
+func limit(values []string, sep string) string {
+	var sb strings.Builder
+
+	for _, v := range values {
+		if sb.Len()+len(sep)+len(v) > MaxCarrierValueSize {
+			break
+		}
+
+		if sb.Len() > 0 {
+			sb.WriteString(sep)
+		}
+
+		sb.WriteString(v)
+	}
+
+	return sb.String()
+}
+
+// truncate cuts s to at most n bytes keeping it valid UTF-8.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return s[:n]
+}
+
+func unescape(s string) string {
+	var out, err = url.QueryUnescape(s)
+	if err != nil {
+		return s
+	}
+
+	return out
+}
+
+func parseId(s string) uint32 {
+	// malformed id just makes the error unequal to any factory
+	var id, _ = strconv.ParseUint(s, 10, 32)
+	return uint32(id)
+}
+
+func removeLabel(labels LabelList, label Label) LabelList {
+	var out = make(LabelList, 0, len(labels))
+	for _, l := range labels {
+		if l != label {
+			out = append(out, l)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,76 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+func (suite *ErrorsSuite) TestInjectExtract() {
+	var (
+		accountMissing = WithCode(NewNotFoundFactory("account %s is missing"), "ACC:404")
+		dbFailure      = WithCode(NewPersistenceFactory("query failed"), "DB-1")
+		err            = accountMissing.New("kek, bek").
+				WithLabels("billing").
+				WithDetails(map[string]string{"account": "a&b=c", "tenant": "acme"}).
+				Wrap(dbFailure.New().Wrap(errors.New("connection reset")))
+	)
+
+	for _, carrier := range []Carrier{MapCarrier{}, HeaderCarrier(http.Header{})} {
+		Inject(err, carrier)
+
+		for _, k := range carrier.Keys() {
+			suite.Require().NotContains(carrier.Get(k), "\n")
+			suite.Require().NotContains(carrier.Get(k), " ")
+		}
+
+		var extracted = Extract(carrier)
+		suite.Require().NotNil(extracted)
+		suite.Require().Equal(ErrKindNotFound, KindOf(extracted))
+		suite.Require().Equal("ACC:404", Code(extracted))
+		suite.Require().Equal("account kek, bek is missing: query failed", extracted.Error())
+		suite.Require().Equal(LabelList{LabelUserFriendly, "billing"}, extracted.Labels())
+		suite.Require().Equal(map[string]string{"account": "a&b=c", "tenant": "acme"}, extracted.Details())
+		suite.Require().True(Is(extracted, accountMissing))
+		suite.Require().True(Is(extracted, dbFailure))
+		suite.Require().False(Is(extracted, NewNotFoundFactory("account %s is gone")))
+	}
+}
+
+func (suite *ErrorsSuite) TestInjectExtractNotUserFriendly() {
+	var carrier = MapCarrier{}
+
+	suite.Require().Nil(Extract(carrier))
+
+	Inject(nil, carrier)
+	suite.Require().Empty(carrier)
+
+	Inject(NewTimeoutError("timed out"), carrier)
+
+	var extracted = Extract(carrier)
+	suite.Require().Equal(ErrKindTimeout, KindOf(extracted))
+	suite.Require().Equal(DefaultUserFriendlyError, extracted.Error())
+	suite.Require().False(IsUserFriendly(extracted))
+	suite.Require().True(Is(extracted, NewTimeoutError("timed out")))
+}
+
+func (suite *ErrorsSuite) TestInjectLimits() {
+	var (
+		carrier = MapCarrier{}
+		details = make(map[string]string)
+	)
+
+	for _, k := range []string{"a", "b", "c"} {
+		details[k] = strings.Repeat(k, MaxCarrierValueSize/2)
+	}
+
+	Inject(NewNotFoundFactory("%s").New(strings.Repeat("ы", MaxCarrierValueSize)).WithDetails(details), carrier)
+
+	for _, k := range carrier.Keys() {
+		suite.Require().LessOrEqual(len(carrier.Get(k)), MaxCarrierValueSize)
+	}
+
+	var extracted = Extract(carrier)
+	suite.Require().Len(extracted.Details(), 1)
+	suite.Require().True(strings.HasPrefix(extracted.Error(), "ыы"))
+}
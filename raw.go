@@ -21,26 +21,51 @@ type raw struct {
 }
 
 func (self *raw) Error() string {
-	var (
-		err = error(self.err)
-		out []string
-	)
+	var out = rawMessages(self.err)
+
+	if len(out) == 0 {
+		return DefaultUserFriendlyError
+	}
+
+	return strings.Join(out, ": ")
+}
+
+// rawMessages collects messages of the chain, messages of joined causes are separated with "; ".
+func rawMessages(err error) []string {
+	var out []string
 
 	for err != nil {
-		if t, ok := err.(*implementation); ok {
+		switch t := err.(type) {
+		case *implementation:
 			out = append(out, t.String())
-		} else {
+		case *boundary:
+		case interface{ Unwrap() []error }:
+			if causes := joinMessages(t.Unwrap(), rawMessages); causes != "" {
+				out = append(out, causes)
+			}
+
+			return out
+		default:
 			out = append(out, err.Error())
 		}
 
 		err = errors.Unwrap(err)
 	}
 
-	if len(out) == 0 {
-		return DefaultUserFriendlyError
+	return out
+}
+
+// joinMessages joins messages of the causes collected by the walker.
+func joinMessages(causes []error, walk func(error) []string) string {
+	var out = make([]string, 0, len(causes))
+
+	for _, cause := range causes {
+		if messages := walk(cause); len(messages) > 0 {
+			out = append(out, strings.Join(messages, ": "))
+		}
 	}
 
-	return strings.Join(out, ": ")
+	return strings.Join(out, "; ")
 }
 
 func (self *raw) Location() string {
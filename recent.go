@@ -0,0 +1,150 @@
+package errors
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// RecentError is a group of recently reported errors sharing the same fingerprint.
+type RecentError struct {
+	Fingerprint string         `json:"fingerprint"`
+	Kind        string         `json:"kind"`
+	Code        string         `json:"code"`
+	Labels      LabelList      `json:"labels"`
+	Count       uint64         `json:"count"`
+	FirstSeen   time.Time      `json:"first_seen"`
+	LastSeen    time.Time      `json:"last_seen"`
+	Message     string         `json:"message"`
+	StackTrace  jsontext.Value `json:"stack_trace,omitempty"`
+}
+
+// RecentFilter selects recent errors, empty fields match everything.
+type RecentFilter struct {
+	Kind  string
+	Label Label
+}
+
+func (self RecentFilter) match(e *RecentError) bool {
+	return (self.Kind == "" || self.Kind == e.Kind) && (self.Label == "" || e.Labels.Has(self.Label))
+}
+
+// RecentErrors is a bounded store of recently reported errors grouped by fingerprint.
+// It is a Sink, register it in a reporter to collect errors.
+// When the store is full, the group seen least recently is evicted.
+type RecentErrors struct {
+	mu       sync.Mutex
+	capacity int
+	groups   map[string]*RecentError
+}
+
+// NewRecentErrors makes a store of at most capacity error groups.
+func NewRecentErrors(capacity int) *RecentErrors {
+	return &RecentErrors{
+		capacity: max(capacity, 1),
+		groups:   make(map[string]*RecentError, capacity),
+	}
+}
+
+func (self *RecentErrors) Write(event Event) error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if group, ok := self.groups[event.Fingerprint]; ok {
+		group.Count++
+		group.LastSeen = event.Timestamp
+		group.Message = event.Message
+		group.StackTrace = event.StackTrace
+
+		return nil
+	}
+
+	if len(self.groups) >= self.capacity {
+		self.evict()
+	}
+
+	self.groups[event.Fingerprint] = &RecentError{
+		Fingerprint: event.Fingerprint,
+		Kind:        event.Kind,
+		Code:        event.Code,
+		Labels:      event.Labels,
+		Count:       1,
+		FirstSeen:   event.Timestamp,
+		LastSeen:    event.Timestamp,
+		Message:     event.Message,
+		StackTrace:  event.StackTrace,
+	}
+
+	return nil
+}
+
+// Snapshot returns error groups matching the filter, the most recently seen come first.
+func (self *RecentErrors) Snapshot(filter RecentFilter) []RecentError {
+	self.mu.Lock()
+
+	var out = make([]RecentError, 0, len(self.groups))
+	for _, group := range self.groups {
+		if filter.match(group) {
+			out = append(out, *group)
+		}
+	}
+
+	self.mu.Unlock()
+
+	slices.SortFunc(out, func(a, b RecentError) int {
+		return b.LastSeen.Compare(a.LastSeen)
+	})
+
+	return out
+}
+
+// Dump writes all the error groups to w as JSON.
+func (self *RecentErrors) Dump(w io.Writer) error {
+	return json.MarshalWrite(w, self.Snapshot(RecentFilter{}))
+}
+
+// DumpFile writes all the error groups to a file at path as JSON.
+func (self *RecentErrors) DumpFile(path string) error {
+	var file, err = os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err = self.Dump(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// DumpOnPanic dumps the store to a file at path if the goroutine is panicking and re-panics.
+// It has to be deferred directly:
+//
+//	defer recent.DumpOnPanic("/tmp/errors.json")
+func (self *RecentErrors) DumpOnPanic(path string) {
+	if r := recover(); r != nil {
+		// the process is going down anyway, there is nothing to do with a dump failure
+		_ = self.DumpFile(path)
+
+		panic(r)
+	}
+}
+
+func (self *RecentErrors) evict() {
+	var oldest *RecentError
+
+	for _, group := range self.groups {
+		if oldest == nil || group.LastSeen.Before(oldest.LastSeen) {
+			oldest = group
+		}
+	}
+
+	if oldest != nil {
+		delete(self.groups, oldest.Fingerprint)
+	}
+}
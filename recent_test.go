@@ -0,0 +1,72 @@
+package errors
+
+import (
+	"encoding/json/v2"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+func (suite *ErrorsSuite) TestRecentErrors() {
+	var (
+		store    = NewRecentErrors(2)
+		now      = time.Now()
+		notFound = NewNotFoundFactory("user %d not found").WithLabels("repo")
+		events   = []Event{
+			NewEvent(notFound.New(1)),
+			NewEvent(NewTimeoutError("timed out")),
+			NewEvent(notFound.New(2)),
+		}
+	)
+
+	for i := range events {
+		events[i].Timestamp = now.Add(time.Duration(i) * time.Second)
+		suite.Require().NoError(store.Write(events[i]))
+	}
+
+	var groups = store.Snapshot(RecentFilter{})
+	suite.Require().Len(groups, 2)
+	suite.Require().Equal("NotFound", groups[0].Kind)
+	suite.Require().Equal(uint64(2), groups[0].Count)
+	suite.Require().Equal(events[0].Timestamp, groups[0].FirstSeen)
+	suite.Require().Equal(events[2].Timestamp, groups[0].LastSeen)
+	suite.Require().Equal("user 2 not found", groups[0].Message)
+	suite.Require().NotEmpty(groups[0].StackTrace)
+	suite.Require().Equal("Timeout", groups[1].Kind)
+
+	suite.Require().Len(store.Snapshot(RecentFilter{Kind: "Timeout"}), 1)
+	suite.Require().Len(store.Snapshot(RecentFilter{Label: "repo"}), 1)
+	suite.Require().Empty(store.Snapshot(RecentFilter{Kind: "Timeout", Label: "repo"}))
+
+	// the timeout group is the least recently seen one
+	var event = NewEvent(NewValidationError("invalid"))
+	event.Timestamp = now.Add(time.Minute)
+	suite.Require().NoError(store.Write(event))
+
+	groups = store.Snapshot(RecentFilter{})
+	suite.Require().Len(groups, 2)
+	suite.Require().Equal("Validation", groups[0].Kind)
+	suite.Require().Equal("NotFound", groups[1].Kind)
+}
+
+func (suite *ErrorsSuite) TestRecentErrorsDump() {
+	var (
+		store = NewRecentErrors(10)
+		path  = filepath.Join(suite.T().TempDir(), "errors.json")
+	)
+
+	suite.Require().NoError(store.Write(NewEvent(NewTimeoutError("timed out"))))
+
+	suite.Require().Panics(func() {
+		defer store.DumpOnPanic(path)
+		panic("kek")
+	})
+
+	var data, err = os.ReadFile(path)
+	suite.Require().NoError(err)
+
+	var groups []RecentError
+	suite.Require().NoError(json.Unmarshal(data, &groups))
+	suite.Require().Len(groups, 1)
+	suite.Require().Equal("timed out", groups[0].Message)
+}
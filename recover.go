@@ -0,0 +1,134 @@
+package errors
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+const panicTemplate = "panic: %v"
+
+// panicKind is a kind of errors made out of recovered panics.
+var panicKind atomic.Uint64
+
+// SetPanicKind sets a kind of errors made out of recovered panics, ErrKindGeneral by default.
+func SetPanicKind(kind Kind) {
+	panicKind.Store(uint64(kind))
+}
+
+// Recover converts a recovered panic into an Error and stores it into err.
+// It has to be deferred directly to be able to recover:
+//
+//	defer errors.Recover(&err)
+//
+// The panic is raised again if err is nil, there is nowhere to store it.
+func Recover(err *error) {
+	var r = recover()
+	if r == nil {
+		return
+	}
+
+	if err == nil {
+		panic(r)
+	}
+
+	*err = fromPanic(r)
+}
+
+// Catch calls fn and converts its panic, if any, into an Error.
+func Catch(fn func() error) (err error) {
+	defer Recover(&err)
+
+	return fn()
+}
+
+// Go runs fn in a new goroutine. The returned channel receives either fn result
+// or its converted panic and is closed afterwards.
+func Go(fn func() error) <-chan Error {
+	var out = make(chan Error, 1)
+
+	go func() {
+		defer close(out)
+
+		out <- From(Catch(fn))
+	}()
+
+	return out
+}
+
+// SafeGo runs fn in a new goroutine and passes its converted panic, if any, to handler.
+func SafeGo(fn func(), handler func(Error)) {
+	go func() {
+		var err error
+
+		defer func() {
+			if err != nil && handler != nil {
+				handler(From(err))
+			}
+		}()
+		defer Recover(&err)
+
+		fn()
+	}()
+}
+
+func fromPanic(value any) *implementation {
+	var err = &implementation{
+		id:     errorId(panicTemplate),
+		kind:   Kind(panicKind.Load()),
+		labels: LabelList{LabelPanic},
+	}
+
+	switch t := value.(type) {
+	case runtime.Error:
+		err.message = "panic"
+		err.previous = t
+		err.labels = err.labels.Add(LabelRuntime)
+	case error:
+		err.message = "panic"
+		err.previous = t
+	default:
+		err.message = fmt.Sprintf(panicTemplate, t)
+	}
+
+	err.setPanicStack()
+
+	return err
+}
+
+// setPanicStack captures the stack of a panicking goroutine
+// starting from the frame that has called panic.
+func (self *implementation) setPanicStack() {
+	if !stackCapture {
+		return
+	}
+
+	var (
+		pcs = make([]uintptr, maxStackDepth)
+		n   = runtime.Callers(1, pcs)
+	)
+
+	pcs = pcs[:n]
+
+	for i, pc := range pcs {
+		if fn := runtime.FuncForPC(pc - 1); fn == nil || fn.Name() != "runtime.gopanic" {
+			continue
+		}
+
+		// runtime errors are raised by runtime helpers (panicmem, sigpanic etc.), skip them as well
+		for pcs = pcs[i+1:]; len(pcs) > 0; pcs = pcs[1:] {
+			if fn := runtime.FuncForPC(pcs[0] - 1); fn == nil || !strings.HasPrefix(fn.Name(), "runtime.") {
+				break
+			}
+		}
+
+		break
+	}
+
+	self.stack = pcs
+
+	if frame, _ := runtime.CallersFrames(pcs).Next(); frame.File != "" {
+		self.location = location{pc: frame.PC + 1, file: frame.File, line: frame.Line, function: frame.Function}
+	}
+}
@@ -0,0 +1,126 @@
+package errors
+
+import (
+	"encoding/json/v2"
+	"errors"
+	"strings"
+)
+
+func (suite *ErrorsSuite) TestRecover() {
+	var (
+		goErr = errors.New("kek bek")
+		tests = []struct {
+			name       string
+			fn         func() error
+			assertFunc func(error)
+		}{
+			{
+				name: "no panic",
+				fn:   func() error { return nil },
+				assertFunc: func(err error) {
+					suite.Require().NoError(err)
+				},
+			},
+			{
+				name: "returned error",
+				fn:   func() error { return goErr },
+				assertFunc: func(err error) {
+					suite.Require().Equal(goErr, err)
+				},
+			},
+			{
+				name: "error panic",
+				fn:   func() error { panic(goErr) },
+				assertFunc: func(err error) {
+					suite.Require().True(Labels(err).Has(LabelPanic))
+					suite.Require().False(Labels(err).Has(LabelRuntime))
+					suite.Require().True(Is(err, goErr))
+					suite.Require().Equal("panic: kek bek", Raw(err).Error())
+				},
+			},
+			{
+				name: "runtime error panic",
+				fn: func() error {
+					var m map[string]int
+					m["kek"] = 1
+					return nil
+				},
+				assertFunc: func(err error) {
+					suite.Require().True(Labels(err).Has(LabelPanic))
+					suite.Require().True(Labels(err).Has(LabelRuntime))
+					suite.Require().Contains(Raw(err).Error(), "assignment to entry in nil map")
+				},
+			},
+			{
+				name: "value panic",
+				fn:   func() error { panic(100500) },
+				assertFunc: func(err error) {
+					suite.Require().True(Labels(err).Has(LabelPanic))
+					suite.Require().Equal("panic: 100500", Raw(err).Error())
+				},
+			},
+		}
+	)
+
+	for _, t := range tests {
+		suite.Run(t.name, func() {
+			var err = Catch(t.fn)
+			t.assertFunc(err)
+
+			if Labels(err).Has(LabelPanic) {
+				suite.Require().Equal(ErrKindGeneral, KindOf(err))
+				suite.Require().Equal(DefaultUserFriendlyError, err.Error())
+			}
+		})
+	}
+}
+
+func (suite *ErrorsSuite) TestRecoverStack() {
+	suite.requireStacks()
+
+	var err = Catch(func() error { panic("kek") })
+	suite.Require().Error(err)
+
+	var stacker, ok = err.(Stacker)
+	suite.Require().True(ok)
+	suite.Require().Contains(stacker.Location(), "recover_test.go")
+
+	var frames []stackTraceFrame
+	suite.Require().NoError(json.Unmarshal(stacker.StackTrace(), &frames))
+	suite.Require().Len(frames, 1)
+	suite.Require().NotEmpty(frames[0].Stack)
+	suite.Require().True(strings.HasPrefix(frames[0].Stack[0], "github.com/aerario/errors.(*ErrorsSuite).TestRecoverStack"))
+}
+
+func (suite *ErrorsSuite) TestRecoverKind() {
+	SetPanicKind(ErrKindInconsistent)
+	defer SetPanicKind(ErrKindGeneral)
+
+	var err = Catch(func() error { panic("kek") })
+	suite.Require().Equal(ErrKindInconsistent, KindOf(err))
+}
+
+func (suite *ErrorsSuite) TestRecoverNil() {
+	suite.Require().PanicsWithValue("kek", func() {
+		defer Recover(nil)
+		panic("kek")
+	})
+}
+
+func (suite *ErrorsSuite) TestGo() {
+	var err = <-Go(func() error { panic("kek") })
+	suite.Require().True(Labels(err).Has(LabelPanic))
+
+	err = <-Go(func() error { return nil })
+	suite.Require().Nil(err)
+}
+
+func (suite *ErrorsSuite) TestSafeGo() {
+	var out = make(chan Error, 1)
+
+	SafeGo(func() { panic("kek") }, func(err Error) { out <- err })
+
+	var err = <-out
+	suite.Require().True(Labels(err).Has(LabelPanic))
+	suite.Require().Equal("panic: kek", Raw(err).Error())
+}
@@ -0,0 +1,118 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aerario/errors"
+)
+
+// DOT writes the error as a Graphviz digraph, server faults are filled, foreign errors are ellipses.
+func DOT(w io.Writer, err error, opts Options) error {
+	var g = graph{
+		opts:   opts,
+		escape: strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace,
+	}
+
+	g.printf("digraph errors {\n")
+	g.printf("\tnode [shape=box, fontname=\"monospace\"];\n")
+	g.walk(Build(err), 0, func(id string, node *Node, label string) {
+		var attrs = []string{fmt.Sprintf("label=\"%s\"", label)}
+
+		if node != nil && node.Foreign {
+			attrs = append(attrs, "shape=ellipse")
+		}
+
+		if node != nil && errors.IsServerFault(node.Kind) {
+			attrs = append(attrs, "style=filled", "fillcolor=mistyrose")
+		}
+
+		g.printf("\t%s [%s];\n", id, strings.Join(attrs, ", "))
+	}, func(from, to string) {
+		g.printf("\t%s -> %s;\n", from, to)
+	})
+	g.printf("}\n")
+
+	return g.flush(w)
+}
+
+// Mermaid writes the error as a Mermaid flowchart, e.g. to be embedded into markdown documents.
+func Mermaid(w io.Writer, err error, opts Options) error {
+	var g = graph{
+		opts: opts,
+		escape: strings.NewReplacer(
+			`"`, "#quot;", "<", "#lt;", ">", "#gt;", "\n", "<br/>",
+		).Replace,
+	}
+
+	g.printf("flowchart TD\n")
+	g.walk(Build(err), 0, func(id string, node *Node, label string) {
+		if node != nil && node.Foreign {
+			g.printf("\t%s([\"%s\"])\n", id, label)
+		} else {
+			g.printf("\t%s[\"%s\"]\n", id, label)
+		}
+	}, func(from, to string) {
+		g.printf("\t%s --> %s\n", from, to)
+	})
+
+	return g.flush(w)
+}
+
+type graph struct {
+	opts   Options
+	escape func(string) string
+	sb     strings.Builder
+	next   int
+}
+
+func (self *graph) printf(format string, args ...any) {
+	fmt.Fprintf(&self.sb, format, args...)
+}
+
+func (self *graph) flush(w io.Writer) error {
+	var _, err = io.WriteString(w, self.sb.String())
+
+	return err
+}
+
+// walk calls vertex for every node and edge for every cause, the node is nil for a summary of hidden causes.
+func (self *graph) walk(node *Node, depth int, vertex func(id string, node *Node, label string), edge func(from, to string)) string {
+	if node == nil {
+		return ""
+	}
+
+	var id = fmt.Sprintf("n%d", self.next)
+	self.next++
+
+	vertex(id, node, self.label(node.Title(), self.opts.message(node.Message), self.opts.location(node.Location)))
+
+	if n := self.opts.hidden(node, depth); n > 0 {
+		var hidden = fmt.Sprintf("n%d", self.next)
+		self.next++
+
+		vertex(hidden, nil, self.label(fmt.Sprintf("%s %d more", Ellipsis, n)))
+		edge(id, hidden)
+
+		return id
+	}
+
+	for _, c := range node.Causes {
+		edge(id, self.walk(c, depth+1, vertex, edge))
+	}
+
+	return id
+}
+
+func (self *graph) label(lines ...string) string {
+	var out = make([]string, 0, len(lines))
+
+	for _, l := range lines {
+		if l != "" {
+			out = append(out, truncate(l, self.opts.Width))
+		}
+	}
+
+	return self.escape(strings.Join(out, "\n"))
+}
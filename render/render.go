@@ -0,0 +1,185 @@
+// Package render prints error trees for humans: an indented tree for terminals,
+// optionally colored, and Graphviz DOT and Mermaid graphs for documents.
+package render
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aerario/errors"
+)
+
+// Ellipsis marks truncated text.
+const Ellipsis = "…"
+
+// Options limit the size of the rendered output, zero values mean no limit.
+type Options struct {
+	// Width is a maximal length of a line in runes, graph renderers apply it to every line of a node label.
+	Width int
+	// MaxMessage is a maximal length of an error message in runes.
+	MaxMessage int
+	// MaxDepth is a maximal depth of rendered causes, deeper ones are replaced with a summary.
+	MaxDepth int
+	// ShortPaths leaves only file names in locations.
+	ShortPaths bool
+	// Color enables ANSI colors of Tree, by default they are used when writing to a terminal.
+	Color ColorMode
+}
+
+// Node is an error of the tree.
+type Node struct {
+	Kind     errors.Kind
+	Code     string // empty when it is the same as the kind name
+	Message  string
+	Labels   []string
+	Location string
+	Foreign  bool // not an error of this module
+	Causes   []*Node
+}
+
+// Build turns the error and its causes into a tree.
+// Wrappers adding nothing to the messages of their causes, e.g. joins, are skipped.
+func Build(err error) *Node {
+	if err == nil {
+		return nil
+	}
+
+	var node = &Node{Message: err.Error()}
+
+	if e, ok := err.(errors.Error); ok {
+		node.Kind = errors.KindOf(e)
+
+		if code := errors.Code(e); code != node.Kind.String() {
+			node.Code = code
+		}
+
+		if t, ok := err.(fmt.Stringer); ok {
+			node.Message = t.String()
+		}
+
+		for _, l := range e.Labels() {
+			node.Labels = append(node.Labels, string(l))
+		}
+	} else {
+		node.Kind = errors.ErrKindGeneral
+		node.Foreign = true
+	}
+
+	if t, ok := err.(errors.Stacker); ok {
+		node.Location = t.Location()
+	}
+
+	for _, c := range causes(err) {
+		node.Causes = append(node.Causes, expand(c)...)
+	}
+
+	return node
+}
+
+// expand builds nodes of the error, or of its causes if the error is transparent.
+func expand(err error) []*Node {
+	var cs = causes(err)
+
+	if !transparent(err, cs) {
+		return []*Node{Build(err)}
+	}
+
+	var out []*Node
+	for _, c := range cs {
+		out = append(out, expand(c)...)
+	}
+
+	return out
+}
+
+func causes(err error) []error {
+	switch t := err.(type) {
+	case interface{ Unwrap() []error }:
+		var out []error
+		for _, c := range t.Unwrap() {
+			if c != nil {
+				out = append(out, c)
+			}
+		}
+
+		return out
+	case interface{ Unwrap() error }:
+		if c := t.Unwrap(); c != nil {
+			return []error{c}
+		}
+	}
+
+	return nil
+}
+
+// transparent reports whether the error message is just messages of its causes.
+func transparent(err error, causes []error) bool {
+	if _, ok := err.(errors.Error); ok || len(causes) == 0 {
+		return false
+	}
+
+	var messages = make([]string, len(causes))
+	for i, c := range causes {
+		messages[i] = c.Error()
+	}
+
+	return err.Error() == strings.Join(messages, "\n")
+}
+
+// Title returns the kind and code of the node.
+func (self *Node) Title() string {
+	if self.Code != "" {
+		return self.Kind.String() + " " + self.Code
+	}
+
+	return self.Kind.String()
+}
+
+// count returns the number of nodes in the tree.
+func (self *Node) count() int {
+	var n = 1
+	for _, c := range self.Causes {
+		n += c.count()
+	}
+
+	return n
+}
+
+// hidden returns the number of nodes cut off by MaxDepth below the node at the depth.
+func (self Options) hidden(node *Node, depth int) int {
+	if self.MaxDepth <= 0 || depth < self.MaxDepth {
+		return 0
+	}
+
+	return node.count() - 1
+}
+
+func (self Options) message(s string) string {
+	s = strings.Join(strings.Fields(strings.ReplaceAll(s, "\n", "; ")), " ")
+
+	return truncate(s, self.MaxMessage)
+}
+
+func (self Options) location(s string) string {
+	if self.ShortPaths && s != "" {
+		return filepath.Base(s)
+	}
+
+	return s
+}
+
+// truncate limits the string to width runes including the ellipsis.
+func truncate(s string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(s) <= width {
+		return s
+	}
+
+	var runes = []rune(s)
+	if width == 1 {
+		return Ellipsis
+	}
+
+	return string(runes[:width-1]) + Ellipsis
+}
@@ -0,0 +1,146 @@
+package render
+
+import (
+	"bytes"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/aerario/errors"
+)
+
+type RenderSuite struct {
+	suite.Suite
+}
+
+func TestRenderSuite(t *testing.T) {
+	suite.Run(t, new(RenderSuite))
+}
+
+func (suite *RenderSuite) tree() error {
+	var (
+		db    = stderrors.New("connection refused")
+		cache = fmt.Errorf("cache: %w", stderrors.New("timeout"))
+	)
+
+	return errors.WithCode(errors.NewNotFoundFactory("user %d not found"), "USER-404").New(42).
+		Wrap(errors.Aggregate(
+			errors.NewInfrastructureError("db is down").Wrap(db),
+			errors.NewTimeoutError("cache is slow").Wrap(cache),
+		))
+}
+
+func (suite *RenderSuite) TestBuild() {
+	var node = Build(suite.tree())
+
+	suite.Require().Equal(errors.ErrKindNotFound, node.Kind)
+	suite.Require().Equal("USER-404", node.Code)
+	suite.Require().Equal("user 42 not found", node.Message)
+	suite.Require().Equal([]string{"user-friendly"}, node.Labels)
+	if errors.CapturesStacks() {
+		suite.Require().Contains(node.Location, "render_test.go:")
+	}
+
+	// aggregate -> join is skipped
+	suite.Require().Len(node.Causes, 1)
+	suite.Require().Equal(errors.ErrKindInfrastructure, node.Causes[0].Kind)
+	suite.Require().Len(node.Causes[0].Causes, 2)
+	suite.Require().Equal("db is down", node.Causes[0].Causes[0].Message)
+	suite.Require().Equal("cache: timeout", node.Causes[0].Causes[1].Causes[0].Message)
+	suite.Require().True(node.Causes[0].Causes[1].Causes[0].Foreign)
+	suite.Require().Len(node.Causes[0].Causes[1].Causes[0].Causes, 1)
+
+	suite.Require().Nil(Build(nil))
+}
+
+func (suite *RenderSuite) TestTree() {
+	if !errors.CapturesStacks() {
+		suite.T().Skip("errors_nostack build captures no locations")
+	}
+
+	var out = TreeString(suite.tree(), Options{ShortPaths: true})
+
+	var lines = strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	suite.Require().Len(lines, 7)
+	suite.Require().Regexp(`^NotFound USER-404: user 42 not found \[user-friendly\] render_test\.go:\d+$`, lines[0])
+	suite.Require().Regexp(`^└─ Infrastructure: 2 errors occurred$`, lines[1])
+	suite.Require().Regexp(`^   ├─ Infrastructure: db is down render_test\.go:\d+$`, lines[2])
+	suite.Require().Equal(`   │  └─ General: connection refused`, lines[3])
+	suite.Require().Regexp(`^   └─ Timeout: cache is slow`, lines[4])
+	suite.Require().Equal(`      └─ General: cache: timeout`, lines[5])
+	suite.Require().Equal(`         └─ General: timeout`, lines[6])
+}
+
+func (suite *RenderSuite) TestTreeLimits() {
+	var out = TreeString(suite.tree(), Options{Width: 30, MaxMessage: 8, MaxDepth: 1})
+
+	suite.Require().Equal("NotFound USER-404: user 42… […\n"+
+		"└─ Infrastructure: 2 error…\n"+
+		"   └─ … 5 more\n", out)
+
+	for _, l := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		suite.Require().LessOrEqual(len([]rune(l)), 30)
+	}
+}
+
+func (suite *RenderSuite) TestColor() {
+	var buf bytes.Buffer
+
+	suite.Require().NoError(Tree(&buf, errors.NewNotFoundError("kek").WithLabels("users"), Options{Color: ColorAlways}))
+	suite.Require().Contains(buf.String(), ansiBold+ansiYellow+"NotFound"+ansiReset)
+	suite.Require().Contains(buf.String(), ansiCyan+" [users]"+ansiReset)
+
+	buf.Reset()
+	suite.Require().NoError(Tree(&buf, errors.NewInfrastructureError("kek"), Options{Color: ColorAlways}))
+	suite.Require().Contains(buf.String(), ansiBold+ansiRed+"Infrastructure"+ansiReset)
+
+	// not a terminal
+	buf.Reset()
+	suite.Require().NoError(Tree(&buf, errors.NewNotFoundError("kek"), Options{}))
+	suite.Require().NotContains(buf.String(), "\x1b")
+
+	suite.Require().False(IsTerminal(&buf))
+	suite.Require().False(IsTerminal(os.NewFile(^uintptr(0), "kek")))
+}
+
+func (suite *RenderSuite) TestDOT() {
+	if !errors.CapturesStacks() {
+		suite.T().Skip("errors_nostack build captures no locations")
+	}
+
+	var buf bytes.Buffer
+
+	suite.Require().NoError(DOT(&buf, errors.NewNotFoundError(`"kek"`).Wrap(stderrors.New("lol")), Options{ShortPaths: true}))
+
+	var out = buf.String()
+	suite.Require().True(strings.HasPrefix(out, "digraph errors {\n"))
+	suite.Require().Regexp(`n0 \[label="NotFound\\n\\"kek\\"\\nrender_test\.go:\d+"\];`, out)
+	suite.Require().Contains(out, `n1 [label="General\nlol", shape=ellipse, style=filled, fillcolor=mistyrose];`)
+	suite.Require().Contains(out, "n0 -> n1;")
+	suite.Require().True(strings.HasSuffix(out, "}\n"))
+
+	buf.Reset()
+	suite.Require().NoError(DOT(&buf, suite.tree(), Options{MaxDepth: 1, Width: 5}))
+	suite.Require().Contains(buf.String(), `n2 [label="… 5 …"];`)
+	suite.Require().Contains(buf.String(), "n1 -> n2;")
+}
+
+func (suite *RenderSuite) TestMermaid() {
+	if !errors.CapturesStacks() {
+		suite.T().Skip("errors_nostack build captures no locations")
+	}
+
+	var buf bytes.Buffer
+
+	suite.Require().NoError(Mermaid(&buf, errors.NewNotFoundError(`<"kek">`).Wrap(stderrors.New("lol")), Options{ShortPaths: true}))
+
+	var out = buf.String()
+	suite.Require().True(strings.HasPrefix(out, "flowchart TD\n"))
+	suite.Require().Regexp(`n0\["NotFound<br/>#lt;#quot;kek#quot;#gt;<br/>render_test\.go:\d+"\]`, out)
+	suite.Require().Contains(out, `n1(["General<br/>lol"])`)
+	suite.Require().Contains(out, "n0 --> n1")
+}
@@ -0,0 +1,188 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aerario/errors"
+)
+
+type ColorMode int
+
+const (
+	ColorAuto ColorMode = iota // colors are used when writing to a terminal and NO_COLOR is not set
+	ColorAlways
+	ColorNever
+)
+
+// ANSI escape sequences used by the colored tree.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiDim    = "\x1b[2m"
+	ansiRed    = "\x1b[31m"
+	ansiYellow = "\x1b[33m"
+	ansiPurple = "\x1b[35m"
+	ansiCyan   = "\x1b[36m"
+)
+
+// IsTerminal reports whether the writer is a terminal.
+func IsTerminal(w io.Writer) bool {
+	var f, ok = w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	var info, err = f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func (self ColorMode) enabled(w io.Writer) bool {
+	switch self {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	if _, ok := os.LookupEnv("NO_COLOR"); ok || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	return IsTerminal(w)
+}
+
+// segment is a piece of a line printed in a single color.
+type segment struct {
+	text  string
+	color string
+}
+
+// Tree writes the error as an indented tree, one line per error:
+//
+//	NotFound USER-404: user 42 not found [user-friendly] users.go:42
+//	└─ General: sql: no rows in result set
+func Tree(w io.Writer, err error, opts Options) error {
+	var node = Build(err)
+	if node == nil {
+		return nil
+	}
+
+	var p = treePrinter{w: w, opts: opts, color: opts.Color.enabled(w)}
+	p.node(node, "", "", 0)
+
+	return p.err
+}
+
+// TreeString returns the error as an uncolored indented tree.
+func TreeString(err error, opts Options) string {
+	var sb strings.Builder
+
+	opts.Color = ColorNever
+	_ = Tree(&sb, err, opts)
+
+	return sb.String()
+}
+
+type treePrinter struct {
+	w     io.Writer
+	opts  Options
+	color bool
+	err   error
+}
+
+func (self *treePrinter) node(node *Node, prefix, branch string, depth int) {
+	var kindColor = ansiYellow
+	if errors.IsServerFault(node.Kind) {
+		kindColor = ansiRed
+	}
+
+	var line = []segment{
+		{text: prefix + branch},
+		{text: node.Title(), color: ansiBold + kindColor},
+		{text: ": " + self.opts.message(node.Message)},
+	}
+
+	if len(node.Labels) > 0 {
+		line = append(line, segment{text: " [" + strings.Join(node.Labels, ", ") + "]", color: ansiCyan})
+	}
+
+	if loc := self.opts.location(node.Location); loc != "" {
+		line = append(line, segment{text: " " + loc, color: ansiDim})
+	}
+
+	self.line(line)
+
+	switch branch {
+	case "├─ ":
+		prefix += "│  "
+	case "└─ ":
+		prefix += "   "
+	}
+
+	if n := self.opts.hidden(node, depth); n > 0 {
+		self.line([]segment{{text: prefix + "└─ "}, {text: fmt.Sprintf("%s %d more", Ellipsis, n), color: ansiDim}})
+		return
+	}
+
+	for i, c := range node.Causes {
+		if i == len(node.Causes)-1 {
+			self.node(c, prefix, "└─ ", depth+1)
+		} else {
+			self.node(c, prefix, "├─ ", depth+1)
+		}
+	}
+}
+
+// line writes segments truncated to the width.
+func (self *treePrinter) line(segments []segment) {
+	if self.err != nil {
+		return
+	}
+
+	var (
+		sb    strings.Builder
+		left  = self.opts.Width
+		total = 0
+	)
+
+	for _, s := range segments {
+		total += utf8.RuneCountInString(s.text)
+	}
+
+	for _, s := range segments {
+		var (
+			text = s.text
+			cut  = false
+		)
+
+		if self.opts.Width > 0 && total > self.opts.Width {
+			if n := utf8.RuneCountInString(text); n < left {
+				left -= n
+			} else {
+				text, cut = truncate(text+" ", left), true
+			}
+		}
+
+		if self.color && s.color != "" {
+			sb.WriteString(s.color + text + ansiReset)
+		} else {
+			sb.WriteString(text)
+		}
+
+		if cut {
+			break
+		}
+	}
+
+	sb.WriteByte('\n')
+
+	_, self.err = io.WriteString(self.w, sb.String())
+}
@@ -0,0 +1,296 @@
+package errors
+
+import (
+	"context"
+	"encoding/json/jsontext"
+	"os"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultReporterQueueSize is a queue size of reporters created with non-positive queue size.
+var DefaultReporterQueueSize = 1024
+
+// Reporter delivers errors to some external storage.
+type Reporter interface {
+	Report(ctx context.Context, err error)
+}
+
+// Event is a snapshot of an error passed to sinks.
+type Event struct {
+	Fingerprint string            `json:"fingerprint"`
+	Kind        string            `json:"kind"`
+	Code        string            `json:"code"`
+	Message     string            `json:"message"`
+	Labels      LabelList         `json:"labels"`
+	Details     map[string]string `json:"details,omitempty"`
+	StackTrace  jsontext.Value    `json:"stack_trace,omitempty"`
+	Build       BuildInfo         `json:"build"`
+	Hostname    string            `json:"hostname,omitempty"`
+	Timestamp   time.Time         `json:"timestamp"`
+}
+
+// BuildInfo describes the binary that has reported an error.
+type BuildInfo struct {
+	GoVersion string `json:"go_version,omitempty"`
+	Path      string `json:"path,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Revision  string `json:"revision,omitempty"`
+	Modified  bool   `json:"modified,omitempty"`
+}
+
+var (
+	buildInfo = sync.OnceValue(func() BuildInfo {
+		var info, ok = debug.ReadBuildInfo()
+		if !ok {
+			return BuildInfo{}
+		}
+
+		var out = BuildInfo{
+			GoVersion: info.GoVersion,
+			Path:      info.Main.Path,
+			Version:   info.Main.Version,
+		}
+
+		for _, s := range info.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				out.Revision = s.Value
+			case "vcs.modified":
+				out.Modified = s.Value == "true"
+			}
+		}
+
+		return out
+	})
+
+	hostname = sync.OnceValue(func() string {
+		// hostname is optional, so the error is ignored
+		var name, _ = os.Hostname()
+		return name
+	})
+)
+
+// NewEvent makes an event out of a non-nil error.
+func NewEvent(err error) Event {
+	var t = convert(err)
+
+	return Event{
+		Fingerprint: Fingerprint(err),
+		Kind:        kindName(t.kind),
+		Code:        Code(t),
+		Message:     Raw(t).Error(),
+		Labels:      t.labels,
+		Details:     t.Details(),
+		StackTrace:  t.StackTrace(),
+		Build:       buildInfo(),
+		Hostname:    hostname(),
+		Timestamp:   time.Now(),
+	}
+}
+
+// AsyncReporter delivers events to its sinks in a background goroutine.
+// Events are dropped when its queue is full or the reporter is closed.
+type AsyncReporter struct {
+	queue chan any
+	stop  chan struct{}
+
+	// closing guards closed, so that no event is enqueued after the reporter is stopped
+	closing sync.RWMutex
+	closed  bool
+
+	mu    sync.RWMutex
+	sinks []Sink
+
+	dropped atomic.Uint64
+	failed  atomic.Uint64
+}
+
+// NewReporter starts an AsyncReporter with a queue of the given size.
+func NewReporter(queueSize int, sinks ...Sink) *AsyncReporter {
+	if queueSize <= 0 {
+		queueSize = DefaultReporterQueueSize
+	}
+
+	var r = &AsyncReporter{
+		queue: make(chan any, queueSize),
+		stop:  make(chan struct{}),
+		sinks: sinks,
+	}
+
+	go r.run()
+
+	return r
+}
+
+// AddSink registers one more sink.
+func (self *AsyncReporter) AddSink(sink Sink) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.sinks = append(self.sinks, sink)
+}
+
+// Report enqueues an event made out of err, nil errors are ignored.
+func (self *AsyncReporter) Report(_ context.Context, err error) {
+	if err == nil {
+		return
+	}
+
+	self.closing.RLock()
+	defer self.closing.RUnlock()
+
+	if self.closed {
+		self.dropped.Add(1)
+		return
+	}
+
+	select {
+	case self.queue <- NewEvent(err):
+	default:
+		self.dropped.Add(1)
+	}
+}
+
+// Dropped returns the number of events dropped because of the full queue or the closed reporter.
+func (self *AsyncReporter) Dropped() uint64 {
+	return self.dropped.Load()
+}
+
+// Failed returns the number of events sinks have failed to write.
+func (self *AsyncReporter) Failed() uint64 {
+	return self.failed.Load()
+}
+
+// Flush waits until all the events enqueued before the call are delivered.
+func (self *AsyncReporter) Flush(ctx context.Context) error {
+	var done = make(chan struct{})
+
+	select {
+	case self.queue <- done:
+	case <-self.stop:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-self.stop:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close flushes the queue and stops the reporter, events reported afterwards are dropped.
+func (self *AsyncReporter) Close(ctx context.Context) error {
+	var err = self.Flush(ctx)
+
+	self.closing.Lock()
+	defer self.closing.Unlock()
+
+	if !self.closed {
+		self.closed = true
+		close(self.stop)
+	}
+
+	return err
+}
+
+func (self *AsyncReporter) run() {
+	for {
+		select {
+		case <-self.stop:
+			self.drain()
+			return
+		case item := <-self.queue:
+			switch t := item.(type) {
+			case Event:
+				self.deliver(t)
+			case chan struct{}:
+				close(t)
+			}
+		}
+	}
+}
+
+// drain drops events left in the queue of the closed reporter, e.g. those reported while it was flushed.
+func (self *AsyncReporter) drain() {
+	for {
+		select {
+		case item := <-self.queue:
+			switch t := item.(type) {
+			case Event:
+				self.dropped.Add(1)
+			case chan struct{}:
+				close(t)
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (self *AsyncReporter) deliver(event Event) {
+	self.mu.RLock()
+	defer self.mu.RUnlock()
+
+	for _, sink := range self.sinks {
+		if err := sink.Write(event); err != nil {
+			self.failed.Add(1)
+		}
+	}
+}
+
+var (
+	reporterMu sync.RWMutex
+	reporter   Reporter
+)
+
+// SetReporter sets a reporter used by Report and returns the previous one.
+func SetReporter(r Reporter) Reporter {
+	reporterMu.Lock()
+	defer reporterMu.Unlock()
+
+	var prev = reporter
+	reporter = r
+
+	return prev
+}
+
+// Report passes err to the reporter set by SetReporter and counts it in MetricsOnReport mode.
+func Report(ctx context.Context, err error) {
+	reporterMu.RLock()
+	var r = reporter
+	reporterMu.RUnlock()
+
+	if err == nil {
+		return
+	}
+
+	// reporting an error does not create one, hooks and MetricsOnCreate counters are left alone
+	var e = convert(err)
+
+	countOn(MetricsOnReport, e)
+
+	if r != nil {
+		r.Report(ctx, e)
+	}
+}
+
+// Flush flushes the reporter set by SetReporter if it supports flushing.
+func Flush(ctx context.Context) error {
+	reporterMu.RLock()
+	var r = reporter
+	reporterMu.RUnlock()
+
+	if t, ok := r.(interface{ Flush(context.Context) error }); ok {
+		return t.Flush(ctx)
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json/v2"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func (suite *ErrorsSuite) TestNewEvent() {
+	var (
+		fac = WithCode(NewNotFoundFactory("user %d not found"), "USER-404").WithLabels("repo")
+		err = fac.New(1).Wrap(NewPersistenceError("no rows").WithDetails(map[string]string{"table": "users"}))
+	)
+
+	var event = NewEvent(err)
+	suite.Require().Equal("NotFound", event.Kind)
+	suite.Require().Equal("USER-404", event.Code)
+	suite.Require().Equal("user 1 not found: no rows", event.Message)
+	suite.Require().Equal(LabelList{LabelUserFriendly, "repo"}, event.Labels)
+	suite.Require().Equal(map[string]string{"table": "users"}, event.Details)
+	suite.Require().NotEmpty(event.StackTrace)
+	suite.Require().NotEmpty(event.Build.GoVersion)
+	suite.Require().False(event.Timestamp.IsZero())
+
+	suite.Require().Equal(event.Fingerprint, NewEvent(fac.New(2).Wrap(NewPersistenceError("no rows"))).Fingerprint)
+	suite.Require().NotEqual(event.Fingerprint, NewEvent(fac.New(2)).Fingerprint)
+}
+
+func (suite *ErrorsSuite) TestReporter() {
+	var (
+		sink     = NewMemorySink()
+		reporter = NewReporter(10, sink)
+		ctx      = context.Background()
+	)
+
+	reporter.Report(ctx, NewTimeoutError("timed out"))
+	reporter.Report(ctx, nil)
+	suite.Require().NoError(reporter.Flush(ctx))
+
+	var events = sink.Events()
+	suite.Require().Len(events, 1)
+	suite.Require().Equal("Timeout", events[0].Kind)
+
+	sink.Reset()
+	suite.Require().Empty(sink.Events())
+
+	suite.Require().NoError(reporter.Close(ctx))
+	suite.Require().NoError(reporter.Close(ctx))
+	suite.Require().NoError(reporter.Flush(ctx))
+
+	// events reported after Close are dropped
+	reporter.Report(ctx, NewTimeoutError("timed out"))
+	suite.Require().Equal(uint64(1), reporter.Dropped())
+	suite.Require().Empty(sink.Events())
+}
+
+func (suite *ErrorsSuite) TestReporterDrop() {
+	var (
+		block    = make(chan struct{})
+		reporter = NewReporter(1, SinkFunc(func(Event) error {
+			<-block
+			return os.ErrClosed
+		}))
+		ctx = context.Background()
+	)
+
+	// the first event is being written, the second one is queued
+	reporter.Report(ctx, NewTimeoutError("timed out"))
+
+	for range 10 {
+		reporter.Report(ctx, NewTimeoutError("timed out"))
+	}
+
+	close(block)
+	suite.Require().NoError(reporter.Close(ctx))
+	suite.Require().GreaterOrEqual(reporter.Dropped(), uint64(8))
+	suite.Require().Equal(11-reporter.Dropped(), reporter.Failed())
+}
+
+func (suite *ErrorsSuite) TestGlobalReporter() {
+	var (
+		sink = NewMemorySink()
+		ctx  = context.Background()
+		prev = SetReporter(NewReporter(10, sink))
+	)
+
+	defer SetReporter(prev)
+
+	Report(ctx, NewTimeoutError("timed out"))
+	suite.Require().NoError(Flush(ctx))
+	suite.Require().Len(sink.Events(), 1)
+
+	// reporting a foreign error does not create one
+	var created int
+	defer OnCreate(func(Error) { created++ })()
+
+	ResetMetrics()
+	Report(ctx, io.EOF)
+	suite.Require().NoError(Flush(ctx))
+	suite.Require().Zero(created)
+	suite.Require().Empty(Metrics())
+	suite.Require().Len(sink.Events(), 2)
+	suite.Require().Equal("EOF", sink.Events()[1].Message)
+}
+
+func (suite *ErrorsSuite) TestJSONLinesSink() {
+	var (
+		buf  bytes.Buffer
+		sink = NewJSONLinesSink(&buf)
+	)
+
+	suite.Require().NoError(sink.Write(NewEvent(NewTimeoutError("timed out"))))
+	suite.Require().NoError(sink.Write(NewEvent(NewNotFoundError("not found"))))
+
+	var lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	suite.Require().Len(lines, 2)
+
+	var event Event
+	suite.Require().NoError(json.Unmarshal([]byte(lines[1]), &event))
+	suite.Require().Equal("NotFound", event.Kind)
+	suite.Require().Equal("not found", event.Message)
+}
+
+func (suite *ErrorsSuite) TestFileSink() {
+	var (
+		path      = filepath.Join(suite.T().TempDir(), "errors.log")
+		sink, err = NewFileSink(path, 1, 2)
+	)
+
+	suite.Require().NoError(err)
+
+	for range 4 {
+		suite.Require().NoError(sink.Write(NewEvent(NewTimeoutError("timed out"))))
+	}
+
+	suite.Require().NoError(sink.Close())
+	suite.Require().ErrorIs(sink.Write(NewEvent(NewTimeoutError("timed out"))), os.ErrClosed)
+
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		var data, err = os.ReadFile(name)
+		suite.Require().NoError(err)
+		suite.Require().Equal(1, bytes.Count(data, []byte("\n")))
+	}
+
+	suite.Require().NoFileExists(path + ".3")
+}
+
+func (suite *ErrorsSuite) TestPrettySink() {
+	var (
+		buf  bytes.Buffer
+		sink = NewPrettySink(&buf)
+		err  = WithCode(NewNotFoundFactory("not found"), "USER-404").New().WithDetails(map[string]string{"id": "1"})
+	)
+
+	suite.Require().NoError(sink.Write(NewEvent(err)))
+	suite.Require().Contains(buf.String(), "NotFound [USER-404] not found\n")
+	suite.Require().Contains(buf.String(), "    labels: user-friendly\n")
+	suite.Require().Contains(buf.String(), "    id: 1\n")
+
+	if stackCapture {
+		suite.Require().Contains(buf.String(), "reporter_test.go")
+	}
+}
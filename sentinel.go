@@ -0,0 +1,54 @@
+package errors
+
+import (
+	"maps"
+	"slices"
+)
+
+// NewSentinel returns a shared immutable error without location, suitable for package-level declarations:
+//
+//	var ErrCacheMiss = errors.NewSentinel(errors.ErrKindNotFound, "cache miss")
+//
+// Annotate, Wrap, WithLabels and WithDetails of the error return its copies located at their callers,
+// the copies are still equal to the sentinel by Is.
+func NewSentinel(kind Kind, message string) Error {
+	return &implementation{
+		id:      errorId(message),
+		kind:    kind,
+		message: message,
+		static:  true,
+	}
+}
+
+// derive returns a mutable copy of a static error, callDepth is counted from the caller of derive.
+func (self *implementation) derive(callDepth int) *implementation {
+	var out = &implementation{
+		id:       self.id,
+		kind:     self.kind,
+		code:     self.code,
+		labels:   slices.Clip(self.labels),
+		message:  self.message,
+		previous: self.previous,
+		details:  maps.Clone(self.details),
+	}
+
+	out.construct(nil, StackDefault, callDepth+1)
+
+	return out
+}
+
+// clone returns a mutable copy of the error keeping its location and stack.
+func (self *implementation) clone() *implementation {
+	return &implementation{
+		id:       self.id,
+		kind:     self.kind,
+		code:     self.code,
+		labels:   slices.Clip(self.labels),
+		message:  self.message,
+		previous: self.previous,
+		details:  maps.Clone(self.details),
+		location: self.location,
+		stack:    self.stack,
+		version:  self.version,
+	}
+}
@@ -0,0 +1,77 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var errCacheMiss = NewSentinel(ErrKindNotFound, "cache miss")
+
+func (suite *ErrorsSuite) TestSentinel() {
+	suite.Require().Equal(ErrKindNotFound, KindOf(errCacheMiss))
+	suite.Require().Equal(DefaultUserFriendlyError, errCacheMiss.Error())
+	suite.Require().Empty(errCacheMiss.(Stacker).Location())
+	suite.Require().True(Is(errCacheMiss, errCacheMiss))
+	suite.Require().True(errors.Is(fmt.Errorf("kek: %w", errCacheMiss), errCacheMiss))
+
+	var (
+		cause   = errors.New("redis is down")
+		wrapped = errCacheMiss.Wrap(cause)
+	)
+
+	suite.Require().NotSame(errCacheMiss, wrapped)
+	suite.Require().True(Is(wrapped, errCacheMiss))
+	suite.Require().True(errors.Is(wrapped, cause))
+	suite.requireLocation(wrapped, "sentinel_test.go")
+	suite.Require().Nil(errCacheMiss.Unwrap())
+
+	var annotated = errCacheMiss.Annotate("key %s", "kek").WithLabels(LabelUserFriendly).
+		WithDetails(map[string]string{"key": "kek"})
+	suite.Require().Equal("cache miss: key kek", annotated.Error())
+	suite.Require().Equal(map[string]string{"key": "kek"}, annotated.Details())
+	suite.Require().True(Is(annotated, errCacheMiss))
+
+	// the sentinel is untouched
+	suite.Require().Equal("cache miss", errCacheMiss.(*implementation).message)
+	suite.Require().Empty(errCacheMiss.Labels())
+	suite.Require().Empty(errCacheMiss.Details())
+	suite.Require().Equal(DefaultUserFriendlyError, errCacheMiss.Error())
+
+	// reading and matching sentinels must not allocate
+	suite.Require().Zero(testing.AllocsPerRun(100, func() { benchString = errCacheMiss.Error() }))
+	suite.Require().Zero(testing.AllocsPerRun(100, func() { benchString = annotated.Error() }))
+	suite.Require().Zero(testing.AllocsPerRun(100, func() { benchBool = Is(wrapped, errCacheMiss) }))
+}
+
+func (suite *ErrorsSuite) TestFactoryStatic() {
+	var users = WithCode(NewNotFoundFactory("user not found"), "USER-404")
+
+	var static = users.Static()
+	suite.Require().Same(static, users.Static())
+	suite.Require().Equal("user not found", static.Error())
+	suite.Require().Equal("USER-404", Code(static))
+	suite.Require().Empty(static.(Stacker).Location())
+	suite.Require().True(Is(static, users))
+	suite.Require().True(Is(users.New(), static))
+
+	var labelled = users.WithLabels("users")
+	suite.Require().NotSame(static, labelled.Static())
+	suite.Require().Equal(LabelList{LabelUserFriendly, "users"}, labelled.Static().Labels())
+	suite.Require().Equal(LabelList{LabelUserFriendly}, static.Labels())
+
+	var copied = static.WithLabels("kek")
+	suite.Require().Equal(LabelList{LabelUserFriendly}, static.Labels())
+	suite.Require().Equal(LabelList{LabelUserFriendly, "kek"}, copied.Labels())
+	suite.Require().Equal("USER-404", Code(copied))
+
+	// templates with verbs are not shown to users
+	var templated = NewNotFoundFactory("user %d not found").Static()
+	suite.Require().Equal(DefaultUserFriendlyError, templated.Error())
+	suite.Require().Equal("user %d not found", Raw(templated).Error())
+	suite.Require().False(IsUserFriendly(templated))
+	suite.Require().Equal("100% not found", NewNotFoundFactory("100%% not found").Static().Error())
+
+	suite.Require().Zero(testing.AllocsPerRun(100, func() { benchString = users.Static().Error() }))
+	suite.Require().Zero(testing.AllocsPerRun(100, func() { benchBool = Is(users.Static(), users) }))
+}
@@ -0,0 +1,360 @@
+// Package sentry exports reported errors to Sentry using its envelope protocol.
+package sentry
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json/v2"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aerario/errors"
+)
+
+const (
+	sdkName    = "aerario.errors"
+	sdkVersion = "1.0.0"
+)
+
+var (
+	ErrInvalidDSN  = errors.NewBadRequestFactory("invalid sentry DSN: %s")
+	ErrStatus      = errors.NewThirdPartiesFactory("sentry responded with status %d")
+	ErrRateLimited = errors.NewLimitExceededFactory("sentry rate limit is active until %s")
+)
+
+// Options configure a Sink.
+type Options struct {
+	// DSN is a project key in form of https://<key>@<host>/<project id>
+	DSN         string
+	Environment string
+	// Release defaults to the main module version
+	Release    string
+	HTTPClient *http.Client
+	// MaxRetries is a number of retries of network failures and 5xx responses
+	MaxRetries int
+	// RetryDelay is a base of the exponential backoff between retries
+	RetryDelay time.Duration
+}
+
+// Sink is an errors.Sink which sends events to Sentry.
+type Sink struct {
+	opts     Options
+	dsn      string
+	endpoint string
+	auth     string
+
+	mu            sync.Mutex
+	disabledUntil time.Time
+
+	now   func() time.Time
+	sleep func(time.Duration)
+}
+
+// NewSink makes a Sink out of options.
+func NewSink(opts Options) (*Sink, error) {
+	var u, err = url.Parse(opts.DSN)
+	if err != nil {
+		return nil, ErrInvalidDSN.New(opts.DSN).Wrap(err)
+	}
+
+	var (
+		key       = u.User.Username()
+		path      = strings.Trim(u.Path, "/")
+		projectId = path[strings.LastIndex(path, "/")+1:]
+	)
+
+	if key == "" || projectId == "" || u.Host == "" {
+		return nil, ErrInvalidDSN.New(opts.DSN)
+	}
+
+	path = strings.TrimSuffix(path, projectId)
+
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = time.Second
+	}
+
+	return &Sink{
+		opts:     opts,
+		dsn:      opts.DSN,
+		endpoint: fmt.Sprintf("%s://%s/%sapi/%s/envelope/", u.Scheme, u.Host, path, projectId),
+		auth: fmt.Sprintf("Sentry sentry_version=7, sentry_client=%s/%s, sentry_key=%s",
+			sdkName, sdkVersion, key),
+		now:   time.Now,
+		sleep: time.Sleep,
+	}, nil
+}
+
+// Write sends an event to Sentry, retrying network failures and server errors.
+// Events are dropped with ErrRateLimited while Sentry asks to back off.
+func (self *Sink) Write(event errors.Event) error {
+	self.mu.Lock()
+	var until = self.disabledUntil
+	self.mu.Unlock()
+
+	if self.now().Before(until) {
+		return ErrRateLimited.New(until.Format(time.RFC3339))
+	}
+
+	var body, err = self.envelope(NewEvent(event, self.opts))
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		var retry bool
+		if retry, err = self.send(body); err == nil || !retry || attempt >= self.opts.MaxRetries {
+			return err
+		}
+
+		self.sleep(self.opts.RetryDelay << attempt)
+	}
+}
+
+func (self *Sink) send(body []byte) (retry bool, err error) {
+	var req *http.Request
+	if req, err = http.NewRequest(http.MethodPost, self.endpoint, bytes.NewReader(body)); err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", self.auth)
+
+	var resp *http.Response
+	if resp, err = self.opts.HTTPClient.Do(req); err != nil {
+		return true, errors.NewThirdPartiesError("sending event to sentry").Wrap(err)
+	}
+
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		var until = self.now().Add(retryAfter(resp.Header))
+
+		self.mu.Lock()
+		self.disabledUntil = until
+		self.mu.Unlock()
+
+		return false, ErrRateLimited.New(until.Format(time.RFC3339))
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return true, ErrStatus.New(resp.StatusCode)
+	case resp.StatusCode >= http.StatusBadRequest:
+		return false, ErrStatus.New(resp.StatusCode)
+	}
+
+	return false, nil
+}
+
+func (self *Sink) envelope(event Event) ([]byte, error) {
+	var payload, err = json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	var header []byte
+	if header, err = json.Marshal(map[string]string{
+		"event_id": event.EventId,
+		"sent_at":  self.now().UTC().Format(time.RFC3339Nano),
+		"dsn":      self.dsn,
+	}); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(header)
+	fmt.Fprintf(&buf, "\n{\"type\":\"event\",\"length\":%d}\n", len(payload))
+	buf.Write(payload)
+	buf.WriteByte('\n')
+
+	return buf.Bytes(), nil
+}
+
+// retryAfter reads a back off duration out of X-Sentry-Rate-Limits or Retry-After headers.
+func retryAfter(header http.Header) time.Duration {
+	const defaultDelay = time.Minute
+
+	if limits := header.Get("X-Sentry-Rate-Limits"); limits != "" {
+		var seconds, _, _ = strings.Cut(limits, ":")
+		if n, err := strconv.Atoi(seconds); err == nil {
+			return time.Duration(n) * time.Second
+		}
+	}
+
+	if n, err := strconv.Atoi(header.Get("Retry-After")); err == nil {
+		return time.Duration(n) * time.Second
+	}
+
+	return defaultDelay
+}
+
+// Event is a Sentry event payload.
+type Event struct {
+	EventId     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Platform    string            `json:"platform"`
+	Level       string            `json:"level"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Message     string            `json:"message,omitempty"`
+	Fingerprint []string          `json:"fingerprint,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Extra       map[string]string `json:"extra,omitempty"`
+	Exception   Exceptions        `json:"exception"`
+	SDK         SDK               `json:"sdk"`
+}
+
+type Exceptions struct {
+	Values []Exception `json:"values"`
+}
+
+type Exception struct {
+	Type       string      `json:"type"`
+	Value      string      `json:"value"`
+	Stacktrace *Stacktrace `json:"stacktrace,omitempty"`
+}
+
+type Stacktrace struct {
+	Frames []Frame `json:"frames"`
+}
+
+type Frame struct {
+	Function string `json:"function,omitempty"`
+	Filename string `json:"filename,omitempty"`
+	AbsPath  string `json:"abs_path,omitempty"`
+	Lineno   int    `json:"lineno,omitempty"`
+	InApp    bool   `json:"in_app"`
+}
+
+type SDK struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// chainFrame mirrors frames of errors.Stacker StackTrace.
+type chainFrame struct {
+	Kind     string   `json:"kind"`
+	Error    string   `json:"error"`
+	Location string   `json:"location"`
+	Function string   `json:"function"`
+	Stack    []string `json:"stack"`
+}
+
+// NewEvent maps a reported event to Sentry event.
+func NewEvent(event errors.Event, opts Options) Event {
+	var out = Event{
+		EventId:     eventId(),
+		Timestamp:   event.Timestamp.UTC().Format(time.RFC3339Nano),
+		Platform:    "go",
+		Level:       Level(errors.ParseKind(event.Kind)),
+		ServerName:  event.Hostname,
+		Release:     opts.Release,
+		Environment: opts.Environment,
+		Message:     event.Message,
+		Fingerprint: []string{event.Fingerprint},
+		Tags: map[string]string{
+			"kind": event.Kind,
+			"code": event.Code,
+		},
+		Extra: event.Details,
+		SDK:   SDK{Name: sdkName, Version: sdkVersion},
+	}
+
+	if out.Release == "" {
+		out.Release = event.Build.Version
+	}
+
+	for _, l := range event.Labels {
+		out.Tags[string(l)] = "true"
+	}
+
+	var chain []chainFrame
+	if err := json.Unmarshal(event.StackTrace, &chain); err != nil || len(chain) == 0 {
+		chain = []chainFrame{{Kind: event.Kind, Error: event.Message}}
+	}
+
+	// sentry expects the outermost exception to be the last one
+	for _, c := range slices.Backward(chain) {
+		out.Exception.Values = append(out.Exception.Values, Exception{
+			Type:       c.Kind,
+			Value:      c.Error,
+			Stacktrace: stacktrace(c),
+		})
+	}
+
+	return out
+}
+
+// Level maps error kind to Sentry level.
+func Level(kind errors.Kind) string {
+	switch {
+	case kind == errors.ErrKindInconsistent:
+		return "fatal"
+	case errors.IsServerFault(kind):
+		return "error"
+	}
+
+	return "warning"
+}
+
+func stacktrace(c chainFrame) *Stacktrace {
+	var frames []Frame
+
+	// stack is ordered from the innermost call, sentry expects the opposite
+	for _, s := range slices.Backward(c.Stack) {
+		var function, loc, _ = strings.Cut(s, " ")
+		frames = append(frames, frame(function, loc))
+	}
+
+	if len(frames) == 0 && c.Location != "" {
+		frames = append(frames, frame(c.Function, c.Location))
+	}
+
+	if len(frames) == 0 {
+		return nil
+	}
+
+	return &Stacktrace{Frames: frames}
+}
+
+func frame(function, loc string) Frame {
+	var out = Frame{
+		Function: function,
+		InApp:    !strings.HasPrefix(function, "runtime."),
+	}
+
+	if i := strings.LastIndexByte(loc, ':'); i >= 0 {
+		if line, err := strconv.Atoi(loc[i+1:]); err == nil {
+			loc, out.Lineno = loc[:i], line
+		}
+	}
+
+	// locations are relative unless errors.PathAbsolute policy is set
+	if path.IsAbs(loc) {
+		out.AbsPath = loc
+	} else {
+		out.Filename = loc
+	}
+
+	return out
+}
+
+func eventId() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+
+	return hex.EncodeToString(id[:])
+}
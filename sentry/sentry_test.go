@@ -0,0 +1,157 @@
+package sentry
+
+import (
+	"bufio"
+	"encoding/json/v2"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/aerario/errors"
+)
+
+type SentrySuite struct {
+	suite.Suite
+}
+
+func TestSentrySuite(t *testing.T) {
+	suite.Run(t, new(SentrySuite))
+}
+
+func (suite *SentrySuite) newSink(handler http.HandlerFunc) *Sink {
+	var server = httptest.NewServer(handler)
+	suite.T().Cleanup(server.Close)
+
+	var sink, err = NewSink(Options{
+		DSN:        strings.Replace(server.URL, "://", "://public@", 1) + "/42",
+		MaxRetries: 2,
+	})
+	suite.Require().NoError(err)
+
+	sink.sleep = func(time.Duration) {}
+
+	return sink
+}
+
+func (suite *SentrySuite) TestNewSink() {
+	var sink, err = NewSink(Options{DSN: "https://key@sentry.example.com/prefix/42"})
+	suite.Require().NoError(err)
+	suite.Require().Equal("https://sentry.example.com/prefix/api/42/envelope/", sink.endpoint)
+	suite.Require().Contains(sink.auth, "sentry_key=key")
+
+	_, err = NewSink(Options{DSN: "https://sentry.example.com/42"})
+	suite.Require().True(errors.Is(err, ErrInvalidDSN))
+}
+
+func (suite *SentrySuite) TestNewEvent() {
+	if !errors.CapturesStacks() {
+		suite.T().Skip("errors_nostack build captures no locations")
+	}
+
+	var err = errors.WithCode(errors.NewNotFoundFactory("user %d not found"), "USER-404").New(1).
+		WithDetails(map[string]string{"id": "1"}).
+		Wrap(errors.NewPersistenceError("no rows"))
+
+	var event = NewEvent(errors.NewEvent(err), Options{Environment: "test"})
+	suite.Require().Equal("warning", event.Level)
+	suite.Require().Equal("test", event.Environment)
+	suite.Require().Equal(map[string]string{"kind": "NotFound", "code": "USER-404", "user-friendly": "true"}, event.Tags)
+	suite.Require().Equal(map[string]string{"id": "1"}, event.Extra)
+	suite.Require().Len(event.Exception.Values, 2)
+	suite.Require().Equal(Exception{
+		Type:  "Persistence",
+		Value: "no rows",
+		Stacktrace: &Stacktrace{Frames: []Frame{{
+			Function: "github.com/aerario/errors/sentry.(*SentrySuite).TestNewEvent",
+			Filename: "sentry/sentry_test.go",
+			Lineno:   event.Exception.Values[0].Stacktrace.Frames[0].Lineno,
+			InApp:    true,
+		}}},
+	}, event.Exception.Values[0])
+	suite.Require().Equal("user 1 not found", event.Exception.Values[1].Value)
+}
+
+func (suite *SentrySuite) TestLevel() {
+	suite.Require().Equal("fatal", Level(errors.ErrKindInconsistent))
+	suite.Require().Equal("error", Level(errors.ErrKindInfrastructure))
+	suite.Require().Equal("warning", Level(errors.ErrKindValidation))
+}
+
+func (suite *SentrySuite) TestWrite() {
+	var sink = suite.newSink(func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal("/api/42/envelope/", r.URL.Path)
+		suite.Equal("application/x-sentry-envelope", r.Header.Get("Content-Type"))
+		suite.Contains(r.Header.Get("X-Sentry-Auth"), "sentry_key=public")
+
+		var scanner = bufio.NewScanner(r.Body)
+		suite.Require().True(scanner.Scan())
+		suite.Require().True(scanner.Scan())
+		suite.Contains(scanner.Text(), `"type":"event"`)
+		suite.Require().True(scanner.Scan())
+
+		var event Event
+		suite.Require().NoError(json.Unmarshal(scanner.Bytes(), &event))
+		suite.Equal("error", event.Level)
+		suite.Equal("no connection", event.Message)
+	})
+
+	suite.Require().NoError(sink.Write(errors.NewEvent(errors.NewInfrastructureError("no connection"))))
+}
+
+func (suite *SentrySuite) TestRetry() {
+	var (
+		calls atomic.Int32
+		sink  = suite.newSink(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.Copy(io.Discard, r.Body)
+
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+			}
+		})
+		event = errors.NewEvent(errors.NewInfrastructureError("no connection"))
+	)
+
+	suite.Require().NoError(sink.Write(event))
+	suite.Require().Equal(int32(3), calls.Load())
+
+	calls.Store(-10)
+
+	var err = sink.Write(event)
+	suite.Require().True(errors.Is(err, ErrStatus))
+	suite.Require().Equal(int32(-7), calls.Load())
+}
+
+func (suite *SentrySuite) TestRateLimit() {
+	var (
+		calls atomic.Int32
+		sink  = suite.newSink(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+		now   = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		event = errors.NewEvent(errors.NewInfrastructureError("no connection"))
+	)
+
+	sink.now = func() time.Time { return now }
+
+	suite.Require().True(errors.Is(sink.Write(event), ErrRateLimited))
+	suite.Require().True(errors.Is(sink.Write(event), ErrRateLimited))
+	suite.Require().Equal(int32(1), calls.Load())
+
+	now = now.Add(31 * time.Second)
+	suite.Require().True(errors.Is(sink.Write(event), ErrRateLimited))
+	suite.Require().Equal(int32(2), calls.Load())
+}
+
+func (suite *SentrySuite) TestRetryAfter() {
+	suite.Require().Equal(time.Minute, retryAfter(http.Header{}))
+	suite.Require().Equal(5*time.Second, retryAfter(http.Header{"Retry-After": {"5"}}))
+	suite.Require().Equal(7*time.Second, retryAfter(http.Header{"X-Sentry-Rate-Limits": {"7:error:organization"}}))
+}
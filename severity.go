@@ -0,0 +1,55 @@
+package errors
+
+import (
+	"slices"
+)
+
+// KindSeverity lists kinds from the most severe to the least one.
+// It is used to pick a single kind out of several errors.
+var KindSeverity = []Kind{
+	ErrKindInconsistent,
+	ErrKindInfrastructure,
+	ErrKindPersistence,
+	ErrKindThirdParties,
+	ErrKindTimeout,
+	ErrKindGeneral,
+	ErrKindLimitExceeded,
+	ErrKindAuthentication,
+	ErrKindAuthorization,
+	ErrKindAlreadyExists,
+	ErrKindNotFound,
+	ErrKindValidation,
+	ErrKindBadRequest,
+}
+
+// MostSevere returns the most severe kind according to KindSeverity.
+func MostSevere(kinds ...Kind) Kind {
+	var (
+		result = ErrKindGeneral
+		rank   = len(KindSeverity)
+	)
+
+	for _, k := range kinds {
+		if i := slices.Index(KindSeverity, k); i >= 0 && i < rank {
+			result, rank = k, i
+		}
+	}
+
+	return result
+}
+
+// IsServerFault reports whether the kind means that the error is caused by the service itself
+// or its dependencies rather than by a client.
+func IsServerFault(kind Kind) bool {
+	switch kind {
+	case ErrKindGeneral,
+		ErrKindInconsistent,
+		ErrKindPersistence,
+		ErrKindInfrastructure,
+		ErrKindThirdParties,
+		ErrKindTimeout:
+		return true
+	}
+
+	return false
+}
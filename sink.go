@@ -0,0 +1,267 @@
+package errors
+
+import (
+	"encoding/json/v2"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Sink receives reported events. Sinks are called from a single reporter goroutine,
+// but a sink shared between reporters has to be safe for concurrent use.
+type Sink interface {
+	Write(Event) error
+}
+
+// SinkFunc is an adapter to use ordinary functions as sinks.
+type SinkFunc func(Event) error
+
+func (self SinkFunc) Write(event Event) error {
+	return self(event)
+}
+
+// NewJSONLinesSink returns a sink that writes events to w as JSON Lines.
+func NewJSONLinesSink(w io.Writer) Sink {
+	return &jsonLinesSink{w: w}
+}
+
+type jsonLinesSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (self *jsonLinesSink) Write(event Event) error {
+	var js, err = json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	_, err = self.w.Write(append(js, '\n'))
+
+	return err
+}
+
+// FileSink writes events to a file as JSON Lines and rotates it once it exceeds the size limit.
+type FileSink struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+	backups int
+	file    *os.File
+	size    int64
+}
+
+// NewFileSink opens or creates a file at path. When the file grows over maxSize bytes,
+// it is renamed to path.1 (path.1 to path.2 and so on), at most backups files are kept.
+func NewFileSink(path string, maxSize int64, backups int) (*FileSink, error) {
+	var sink = &FileSink{
+		path:    path,
+		maxSize: maxSize,
+		backups: backups,
+	}
+
+	if err := sink.open(); err != nil {
+		return nil, err
+	}
+
+	return sink, nil
+}
+
+func (self *FileSink) Write(event Event) error {
+	var js, err = json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	js = append(js, '\n')
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.file == nil {
+		return os.ErrClosed
+	}
+
+	if self.maxSize > 0 && self.size > 0 && self.size+int64(len(js)) > self.maxSize {
+		if err = self.rotate(); err != nil {
+			return err
+		}
+	}
+
+	var n int
+	n, err = self.file.Write(js)
+	self.size += int64(n)
+
+	return err
+}
+
+// Close closes the underlying file.
+func (self *FileSink) Close() error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.file == nil {
+		return nil
+	}
+
+	var err = self.file.Close()
+	self.file = nil
+
+	return err
+}
+
+func (self *FileSink) open() error {
+	var file, err = os.OpenFile(self.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	var info os.FileInfo
+	if info, err = file.Stat(); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	self.file = file
+	self.size = info.Size()
+
+	return nil
+}
+
+func (self *FileSink) rotate() error {
+	if err := self.file.Close(); err != nil {
+		return err
+	}
+
+	self.file = nil
+
+	if self.backups <= 0 {
+		if err := os.Remove(self.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		return self.open()
+	}
+
+	for i := self.backups - 1; i > 0; i-- {
+		var err = os.Rename(fmt.Sprintf("%s.%d", self.path, i), fmt.Sprintf("%s.%d", self.path, i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	if err := os.Rename(self.path, self.path+".1"); err != nil {
+		return err
+	}
+
+	return self.open()
+}
+
+// NewPrettySink returns a sink that writes human-readable events to w.
+func NewPrettySink(w io.Writer) Sink {
+	return &prettySink{w: w}
+}
+
+// NewStderrSink returns a sink that writes human-readable events to stderr.
+func NewStderrSink() Sink {
+	return NewPrettySink(os.Stderr)
+}
+
+type prettySink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (self *prettySink) Write(event Event) error {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%s %s", event.Timestamp.Format("2006-01-02T15:04:05.000Z07:00"), event.Kind)
+
+	if event.Code != event.Kind {
+		fmt.Fprintf(&sb, " [%s]", event.Code)
+	}
+
+	fmt.Fprintf(&sb, " %s\n", event.Message)
+
+	if len(event.Labels) > 0 {
+		var labels = make([]string, 0, len(event.Labels))
+		for _, l := range event.Labels {
+			labels = append(labels, string(l))
+		}
+
+		fmt.Fprintf(&sb, "    labels: %s\n", strings.Join(labels, ", "))
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(event.Details)) {
+		fmt.Fprintf(&sb, "    %s: %s\n", k, event.Details[k])
+	}
+
+	var frames []stackTraceFrame
+	if err := json.Unmarshal(event.StackTrace, &frames); err == nil {
+		for _, frame := range frames {
+			fmt.Fprintf(&sb, "    %s: %s", frame.Kind, frame.Error)
+
+			if frame.Location != "" {
+				fmt.Fprintf(&sb, " (%s)", frame.Location)
+			}
+
+			sb.WriteString("\n")
+
+			for _, s := range frame.Stack {
+				fmt.Fprintf(&sb, "        %s\n", s)
+			}
+		}
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	_, err := io.WriteString(self.w, sb.String())
+
+	return err
+}
+
+// MemorySink keeps events in memory, it is meant to be used in tests.
+type MemorySink struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+func (self *MemorySink) Write(event Event) error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.events = append(self.events, event)
+
+	return nil
+}
+
+// Events returns a copy of events written so far.
+func (self *MemorySink) Events() []Event {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	var out = make([]Event, len(self.events))
+	copy(out, self.events)
+
+	return out
+}
+
+// Reset drops all the events.
+func (self *MemorySink) Reset() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.events = nil
+}
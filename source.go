@@ -0,0 +1,139 @@
+package errors
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// PathPolicy defines how file paths of locations are printed.
+type PathPolicy int32
+
+const (
+	// PathRelative trims paths to be relative to the main module,
+	// files of other modules are prefixed with their package path.
+	PathRelative PathPolicy = iota
+	// PathAbsolute keeps paths as recorded by the compiler, they reveal the layout of the build machine.
+	PathAbsolute
+)
+
+var (
+	pathPolicy  atomic.Int32
+	pathPrefix  atomic.Pointer[string]
+	sourceLines atomic.Int32
+	sourceCache sync.Map // file -> []string, nil when it cannot be read
+
+	mainModule = sync.OnceValue(func() string {
+		if info, ok := debug.ReadBuildInfo(); ok {
+			return info.Main.Path
+		}
+
+		return ""
+	})
+)
+
+// SetPathPolicy sets how file paths of locations and stack traces are printed, PathRelative by default.
+func SetPathPolicy(policy PathPolicy) {
+	pathPolicy.Store(int32(policy))
+}
+
+// SetPathPrefix sets a prefix trimmed off file paths, e.g. a checkout directory of the build.
+// It takes precedence over package paths when matches, an empty prefix disables it.
+func SetPathPrefix(prefix string) {
+	pathPrefix.Store(&prefix)
+}
+
+// SetSourceContext enables snippets of n source lines around every location in stack traces.
+// It is meant for development, since sources are read from the running machine, zero disables snippets.
+func SetSourceContext(n int) {
+	sourceLines.Store(int32(n))
+}
+
+// path returns the file path according to the path policy.
+func (loc location) path() string {
+	if loc = loc.resolve(); loc.file == "" || PathPolicy(pathPolicy.Load()) == PathAbsolute {
+		return loc.file
+	}
+
+	if prefix := pathPrefix.Load(); prefix != nil && *prefix != "" && strings.HasPrefix(loc.file, *prefix) {
+		return strings.TrimPrefix(loc.file[len(*prefix):], "/")
+	}
+
+	return loc.relative()
+}
+
+// relative returns the file path relative to the main module or prefixed with the package path.
+func (loc location) relative() string {
+	loc = loc.resolve()
+
+	var (
+		pkg  = strings.TrimSuffix(loc.pkg(), "_test")
+		base = path.Base(loc.file)
+		main = mainModule()
+	)
+
+	switch {
+	case loc.file == "":
+		return ""
+	case pkg == "" || pkg == "main" || pkg == main:
+		return base
+	case main != "" && strings.HasPrefix(pkg, main+"/"):
+		return pkg[len(main)+1:] + "/" + base
+	}
+
+	return pkg + "/" + base
+}
+
+// source returns lines of the source around the location, the line itself is marked with ">".
+func (loc location) source() []string {
+	var n = int(sourceLines.Load())
+	if n <= 0 {
+		return nil
+	}
+
+	if loc = loc.resolve(); loc.file == "" {
+		return nil
+	}
+
+	var lines = sourceFile(loc.file)
+	if loc.line < 1 || loc.line > len(lines) {
+		return nil
+	}
+
+	var (
+		from = max(loc.line-n, 1)
+		to   = min(loc.line+n, len(lines))
+		out  = make([]string, 0, to-from+1)
+	)
+
+	for i := from; i <= to; i++ {
+		var marker = " "
+		if i == loc.line {
+			marker = ">"
+		}
+
+		out = append(out, fmt.Sprintf("%s %4d | %s", marker, i, lines[i-1]))
+	}
+
+	return out
+}
+
+// sourceFile reads the file once and caches its lines.
+func sourceFile(file string) []string {
+	if lines, ok := sourceCache.Load(file); ok {
+		return lines.([]string)
+	}
+
+	var lines []string
+	if data, err := os.ReadFile(file); err == nil {
+		lines = strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	}
+
+	var actual, _ = sourceCache.LoadOrStore(file, lines)
+
+	return actual.([]string)
+}
@@ -0,0 +1,76 @@
+package errors
+
+import (
+	"encoding/json/v2"
+	"runtime"
+	"strings"
+)
+
+func (suite *ErrorsSuite) TestLocationPath() {
+	suite.requireStacks()
+
+	var (
+		err           = NewNotFoundError("kek").(*implementation)
+		_, file, _, _ = runtime.Caller(0)
+	)
+
+	suite.Require().Equal("github.com/aerario/errors.(*ErrorsSuite).TestLocationPath", err.location.resolve().function)
+	suite.Require().Equal(file, err.location.resolve().file)
+	suite.Require().Regexp(`^source_test\.go:\d+$`, err.Location())
+
+	SetPathPolicy(PathAbsolute)
+	suite.Require().Equal(file, err.location.path())
+	SetPathPolicy(PathRelative)
+
+	SetPathPrefix(file[:strings.LastIndexByte(file, '/')])
+	suite.Require().Equal("source_test.go", err.location.path())
+	SetPathPrefix("/nowhere")
+	suite.Require().Equal("source_test.go", err.location.path())
+	SetPathPrefix("")
+
+	var tests = []struct {
+		loc  location
+		want string
+	}{
+		{loc: location{}, want: ""},
+		{loc: location{file: "/build/render/tree.go", function: "github.com/aerario/errors/render.Tree"}, want: "render/tree.go"},
+		{loc: location{file: "/build/kek_test.go", function: "github.com/aerario/errors_test.TestKek"}, want: "kek_test.go"},
+		{loc: location{file: "/go/pkg/mod/github.com/kek/lol@v1.0.0/lol.go", function: "github.com/kek/lol.(*T).Do"}, want: "github.com/kek/lol/lol.go"},
+		{loc: location{file: "/go/pkg/mod/github.com/kek/lol@v1.0.0/lol.go", function: "github.com/kek/lol.Map[github.com/kek/lol/x.T].func1"}, want: "github.com/kek/lol/lol.go"},
+		{loc: location{file: "/build/cmd/kek/main.go", function: "main.main"}, want: "main.go"},
+	}
+
+	for _, t := range tests {
+		suite.Require().Equal(t.want, t.loc.relative(), t.loc.function)
+	}
+}
+
+func (suite *ErrorsSuite) TestSourceContext() {
+	suite.requireStacks()
+
+	var err = NewNotFoundError("kek").Wrap(NewInfrastructureError("lol")).(*implementation)
+
+	var frames []stackTraceFrame
+	suite.Require().NoError(json.Unmarshal(err.StackTrace(), &frames))
+	suite.Require().Equal("github.com/aerario/errors.(*ErrorsSuite).TestSourceContext", frames[0].Function)
+	suite.Require().Empty(frames[0].Source)
+
+	SetSourceContext(1)
+	defer SetSourceContext(0)
+
+	frames = nil
+	suite.Require().NoError(json.Unmarshal(err.StackTrace(), &frames))
+	suite.Require().Len(frames[0].Source, 3)
+	suite.Require().Regexp(`^  +\d+ \| $`, frames[0].Source[0])
+	suite.Require().Regexp(`^> +\d+ \| \tvar err = NewNotFoundError\("kek"\)`, frames[0].Source[1])
+	suite.Require().Regexp(`^  +\d+ \| $`, frames[0].Source[2])
+
+	// cached
+	var lines, ok = sourceCache.Load(err.location.resolve().file)
+	suite.Require().True(ok)
+	suite.Require().NotEmpty(lines)
+
+	var missing = location{file: "/nowhere/kek.go", line: 1}
+	suite.Require().Nil(missing.source())
+	suite.Require().Nil(location{file: err.location.resolve().file, line: 100500}.source())
+}
@@ -0,0 +1,105 @@
+package errors
+
+import (
+	"math"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"sync/atomic"
+)
+
+// StackPolicy defines what is captured when an error is created.
+type StackPolicy int32
+
+const (
+	// StackDefault inherits the policy: factory policy wins over kind policy, kind policy wins over the global one.
+	StackDefault StackPolicy = iota
+	// StackNever captures nothing, errors have no location.
+	StackNever
+	// StackLocation captures the location of the caller only, it is the global policy by default.
+	StackLocation
+	// StackFull captures the whole stack of the caller, subject to sampling, see SetStackSampling.
+	StackFull
+)
+
+// maxStackDepth limits the number of frames captured by StackFull policy.
+const maxStackDepth = 64
+
+var (
+	globalStackPolicy  atomic.Int32
+	kindStackPolicies  [len(kindNames)]atomic.Int32
+	stackSamplingRatio atomic.Uint64 // float64 bits
+)
+
+func init() {
+	globalStackPolicy.Store(int32(StackLocation))
+	stackSamplingRatio.Store(math.Float64bits(1))
+}
+
+// SetStackPolicy sets the global stack policy, StackDefault restores StackLocation.
+func SetStackPolicy(policy StackPolicy) {
+	if policy == StackDefault {
+		policy = StackLocation
+	}
+
+	globalStackPolicy.Store(int32(policy))
+}
+
+// SetKindStackPolicy sets the stack policy of errors of the kind, StackDefault makes them follow the global one.
+func SetKindStackPolicy(kind Kind, policy StackPolicy) {
+	if int(kind) < len(kindStackPolicies) {
+		kindStackPolicies[kind].Store(int32(policy))
+	}
+}
+
+// SetStackSampling sets the ratio of errors with StackFull policy which get the whole stack captured,
+// the others get the location only. It is 1 by default.
+func SetStackSampling(ratio float64) {
+	stackSamplingRatio.Store(math.Float64bits(ratio))
+}
+
+// CapturesStacks reports whether errors get locations and stacks at all, errors_nostack build tag turns it off.
+func CapturesStacks() bool {
+	return stackCapture
+}
+
+// stackPolicyOf resolves the policy of an error of the kind made by a factory with the given policy.
+func stackPolicyOf(kind Kind, policy StackPolicy) StackPolicy {
+	if policy != StackDefault {
+		return policy
+	}
+
+	if int(kind) < len(kindStackPolicies) {
+		if p := StackPolicy(kindStackPolicies[kind].Load()); p != StackDefault {
+			return p
+		}
+	}
+
+	return StackPolicy(globalStackPolicy.Load())
+}
+
+// capture captures the location or the stack of the caller according to the policy.
+func (self *implementation) capture(policy StackPolicy, callDepth int) {
+	if !stackCapture {
+		return
+	}
+
+	switch stackPolicyOf(self.kind, policy) {
+	case StackNever:
+		return
+	case StackFull:
+		if ratio := math.Float64frombits(stackSamplingRatio.Load()); ratio >= 1 || rand.Float64() < ratio {
+			self.setStack(callDepth + 1)
+		}
+	}
+
+	self.setLocation(callDepth + 1)
+}
+
+func (self *implementation) setStack(callDepth int) {
+	var pcs [maxStackDepth]uintptr
+
+	if n := runtime.Callers(callDepth+2, pcs[:]); n > 0 {
+		self.stack = slices.Clone(pcs[:n])
+	}
+}
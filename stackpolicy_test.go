@@ -0,0 +1,86 @@
+package errors
+
+import (
+	"encoding/json/v2"
+	"testing"
+)
+
+func (suite *ErrorsSuite) TestStackPolicy() {
+	suite.requireStacks()
+
+	defer SetStackPolicy(StackDefault)
+	defer SetKindStackPolicy(ErrKindInconsistent, StackDefault)
+	defer SetKindStackPolicy(ErrKindValidation, StackDefault)
+
+	var location = func(err Error) string { return err.(Stacker).Location() }
+	var stack = func(err Error) []string { return err.(*implementation).stack.frames() }
+
+	var err = NewValidationError("kek")
+	suite.Require().NotEmpty(location(err))
+	suite.Require().Empty(stack(err))
+
+	SetKindStackPolicy(ErrKindValidation, StackNever)
+	SetKindStackPolicy(ErrKindInconsistent, StackFull)
+
+	err = NewValidationError("kek")
+	suite.Require().Empty(location(err))
+	suite.Require().Empty(stack(err))
+
+	err = NewInconsistentError("kek")
+	suite.Require().Regexp(`^stackpolicy_test\.go:\d+$`, location(err))
+	suite.Require().Contains(stack(err)[0], "errors.(*ErrorsSuite).TestStackPolicy stackpolicy_test.go:")
+
+	var frames []stackTraceFrame
+	suite.Require().NoError(json.Unmarshal(err.(Stacker).StackTrace(), &frames))
+	suite.Require().NotEmpty(frames[0].Stack)
+
+	// factory policy wins over kind policy
+	err = WithStackPolicy(NewValidationFactory("kek"), StackLocation).New()
+	suite.Require().NotEmpty(location(err))
+
+	err = WithStackPolicy(NewInconsistentFactory("kek"), StackNever).New()
+	suite.Require().Empty(location(err))
+	suite.Require().Empty(stack(err))
+
+	// kind policy wins over global policy
+	SetStackPolicy(StackNever)
+	suite.Require().Empty(location(NewNotFoundError("kek")))
+	suite.Require().NotEmpty(stack(NewInconsistentError("kek")))
+
+	SetStackPolicy(StackFull)
+	suite.Require().NotEmpty(stack(NewNotFoundError("kek")))
+	suite.Require().NotEmpty(stack(New(ErrKindNotFound, "kek")))
+}
+
+func (suite *ErrorsSuite) TestStackSampling() {
+	suite.requireStacks()
+
+	defer SetKindStackPolicy(ErrKindInconsistent, StackDefault)
+	defer SetStackSampling(1)
+
+	SetKindStackPolicy(ErrKindInconsistent, StackFull)
+
+	SetStackSampling(0)
+	var err = NewInconsistentError("kek")
+	suite.Require().NotEmpty(err.(Stacker).Location())
+	suite.Require().Empty(err.(*implementation).stack)
+
+	SetStackSampling(0.5)
+
+	var sampled int
+	for range 1000 {
+		if len(NewInconsistentError("kek").(*implementation).stack) > 0 {
+			sampled++
+		}
+	}
+
+	suite.Require().InDelta(500, sampled, 150)
+}
+
+func (suite *ErrorsSuite) TestStackNeverAllocations() {
+	defer SetKindStackPolicy(ErrKindValidation, StackDefault)
+
+	SetKindStackPolicy(ErrKindValidation, StackNever)
+
+	suite.Require().LessOrEqual(testing.AllocsPerRun(100, func() { benchSink = New(ErrKindValidation, "kek") }), float64(1))
+}
@@ -6,6 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 type Stacker interface {
@@ -20,19 +24,156 @@ type stackTraceFrame struct {
 	Labels   LabelList `json:"labels"`
 	Error    string    `json:"error"`
 	Location string    `json:"location,omitempty"`
+	Function string    `json:"function,omitempty"`
+	Source   []string  `json:"source,omitempty"`
+	Stack    []string  `json:"stack,omitempty"`
+
+	// raw program counters, see SetOfflineSymbolization
+	PC      string   `json:"pc,omitempty"`
+	PCs     []string `json:"pcs,omitempty"`
+	BuildId string   `json:"build_id,omitempty"`
+	Module  string   `json:"module,omitempty"`
+	Anchor  string   `json:"anchor,omitempty"`
 }
 
+// location is captured as a program counter only, it is symbolized by resolve when printed out.
+// Like the ones returned by runtime.Callers, the program counter is a return address.
 type location struct {
-	file string
-	line int
+	pc       uintptr
+	file     string
+	line     int
+	function string
+}
+
+// symbols caches symbolized locations.
+var symbols pcCache[location]
+
+// resolve returns the location with the file, line and function filled.
+func (loc location) resolve() location {
+	if loc.file != "" || loc.pc == 0 {
+		return loc
+	}
+
+	if out, ok := symbols.load(loc.pc); ok {
+		return out
+	}
+
+	var frame, _ = runtime.CallersFrames([]uintptr{loc.pc}).Next()
+
+	var out = location{pc: loc.pc, file: frame.File, line: frame.Line, function: frame.Function}
+	symbols.store(loc.pc, out)
+
+	return out
 }
 
 func (loc location) String() string {
-	return fmt.Sprintf("%s:%d", loc.file, loc.line)
+	if loc = loc.resolve(); loc.file == "" {
+		return ""
+	}
+
+	return loc.path() + ":" + strconv.Itoa(loc.line)
+}
+
+// stack is a list of program counters, it is symbolized only when printed out.
+type stack []uintptr
+
+func (self stack) frames() []string {
+	if len(self) == 0 {
+		return nil
+	}
+
+	var (
+		out    = make([]string, 0, len(self))
+		frames = runtime.CallersFrames(self)
+	)
+
+	for {
+		var frame, more = frames.Next()
+		out = append(out, fmt.Sprintf("%s %s", frame.Function, location{file: frame.File, line: frame.Line, function: frame.Function}))
+
+		if !more {
+			break
+		}
+	}
+
+	return out
+}
+
+// maxHelperDepth limits the number of helper frames walked past by setLocation.
+const maxHelperDepth = 32
+
+var (
+	helpers     sync.Map // function name -> struct{}
+	helperCount atomic.Int32
+)
+
+// Helper marks the calling function as an error helper, like testing.TB.Helper does.
+// Errors created within helpers point at the first caller which is not a helper.
+func Helper() {
+	var pcs [1]uintptr
+	if runtime.Callers(2, pcs[:]) == 0 {
+		return
+	}
+
+	var frame, _ = runtime.CallersFrames(pcs[:]).Next()
+
+	if _, loaded := helpers.LoadOrStore(frame.Function, struct{}{}); !loaded {
+		helperCount.Add(1)
+	}
 }
 
 func (self *implementation) setLocation(callDepth int) {
-	_, self.location.file, self.location.line, _ = runtime.Caller(callDepth + 1)
+	// there is no need to walk the stack until a helper is marked
+	if helperCount.Load() > 0 {
+		self.setHelperLocation(callDepth + 1)
+		return
+	}
+
+	var pcs [1]uintptr
+	if runtime.Callers(callDepth+2, pcs[:]) > 0 {
+		self.location = location{pc: pcs[0]}
+	}
+}
+
+// setHelperLocation captures the location of the first caller which is not a helper.
+func (self *implementation) setHelperLocation(callDepth int) {
+	var pcs [maxHelperDepth]uintptr
+
+	var n = runtime.Callers(callDepth+2, pcs[:])
+	if n == 0 {
+		return
+	}
+
+	var frames = runtime.CallersFrames(pcs[:n])
+
+	for {
+		var frame, more = frames.Next()
+
+		if _, helper := helpers.Load(frame.Function); !helper || !more {
+			self.location = location{pc: frame.PC + 1, file: frame.File, line: frame.Line, function: frame.Function}
+			return
+		}
+	}
+}
+
+// pkg returns an import path of the package the location belongs to.
+func (loc location) pkg() string {
+	loc = loc.resolve()
+
+	// function names look like github.com/aerario/errors.(*implementation).Wrap,
+	// type parameters of generic functions may contain slashes as well
+	var name, _, _ = strings.Cut(loc.function, "[")
+	if name == "" {
+		return ""
+	}
+
+	var slash = strings.LastIndexByte(name, '/') + 1
+
+	if dot := strings.IndexByte(name[slash:], '.'); dot >= 0 {
+		return name[:slash+dot]
+	}
+
+	return name
 }
 
 func (self *implementation) Location() string {
@@ -40,12 +181,31 @@ func (self *implementation) Location() string {
 }
 
 func (self *implementation) StackTrace() jsontext.Value {
-	var (
-		out = make([]stackTraceFrame, 0, 1)
-		err = error(self)
-	)
+	var out = stackTraceFrames(self, make([]stackTraceFrame, 0, 1))
+
+	var js, err = json.Marshal(out)
+	if err != nil {
+		return jsontext.Value(fmt.Sprintf(`{"error":"%s"}`, err.Error()))
+	}
 
+	return js
+}
+
+// stackTraceFrames appends frames of the chain to out, joined causes are appended one after another.
+func stackTraceFrames(err error, out []stackTraceFrame) []stackTraceFrame {
 	for err != nil {
+		switch t := err.(type) {
+		case *boundary:
+			err = t.err
+			continue
+		case interface{ Unwrap() []error }:
+			for _, cause := range t.Unwrap() {
+				out = stackTraceFrames(cause, out)
+			}
+
+			return out
+		}
+
 		var frame = stackTraceFrame{
 			Kind:   kindName(KindOf(err)),
 			Labels: Labels(err),
@@ -57,7 +217,18 @@ func (self *implementation) StackTrace() jsontext.Value {
 			frame.Error = err.Error()
 		}
 
-		if t, ok := err.(Stacker); ok {
+		switch t := err.(type) {
+		case *implementation:
+			if offlineSymbolization.Load() {
+				frame.setPCs(t)
+				break
+			}
+
+			frame.Location = t.Location()
+			frame.Function = t.location.resolve().function
+			frame.Source = t.location.source()
+			frame.Stack = t.stack.frames()
+		case Stacker:
 			frame.Location = t.Location()
 		}
 
@@ -65,10 +236,5 @@ func (self *implementation) StackTrace() jsontext.Value {
 		err = errors.Unwrap(err)
 	}
 
-	var js []byte
-	if js, err = json.Marshal(out); err != nil {
-		return jsontext.Value(fmt.Sprintf(`{"error":"%s"}`, err.Error()))
-	}
-
-	return js
+	return out
 }
@@ -0,0 +1,272 @@
+package errors
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrWorkerEscalated is returned by Supervise when a worker restarts too often,
+// it is an Infrastructure error, so a parent supervisor restarts the whole subtree.
+var ErrWorkerEscalated = NewInfrastructureFactory("worker %q has been restarted %d times within %s")
+
+// ErrWorkerExists is returned by Supervise when a worker of the same name is still running.
+var ErrWorkerExists = NewAlreadyExistsFactory("worker %q is already supervised")
+
+// WorkerState is a state of a supervised worker.
+type WorkerState string
+
+const (
+	WorkerRunning   WorkerState = "running"
+	WorkerBackoff   WorkerState = "backoff"
+	WorkerStopped   WorkerState = "stopped"
+	WorkerFailed    WorkerState = "failed"
+	WorkerEscalated WorkerState = "escalated"
+)
+
+// WorkerStatus describes a supervised worker.
+type WorkerStatus struct {
+	Name        string
+	State       WorkerState
+	Restarts    int
+	StartedAt   time.Time
+	LastError   Error
+	LastErrorAt time.Time
+}
+
+// SupervisorPolicy defines how failed workers are restarted, zero values are replaced with defaults.
+type SupervisorPolicy struct {
+	// MinBackoff is a delay before the first restart, it doubles with each restart up to MaxBackoff.
+	// Defaults are 100 milliseconds and 30 seconds.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// MaxRestarts within Window make the supervisor give up and escalate, zero means no limit.
+	MaxRestarts int
+	// Window defaults to a minute.
+	Window time.Duration
+	// Restart reports whether a worker failed with the error is restarted, IsRestartable by default.
+	Restart func(Error) bool
+}
+
+func (self SupervisorPolicy) withDefaults() SupervisorPolicy {
+	if self.MinBackoff <= 0 {
+		self.MinBackoff = 100 * time.Millisecond
+	}
+
+	if self.MaxBackoff < self.MinBackoff {
+		self.MaxBackoff = max(30*time.Second, self.MinBackoff)
+	}
+
+	if self.Window <= 0 {
+		self.Window = time.Minute
+	}
+
+	if self.Restart == nil {
+		self.Restart = IsRestartable
+	}
+
+	return self
+}
+
+// IsRestartable reports whether a worker failed with the error is worth restarting:
+// the error is retryable (see IsRetryable) or the worker panicked.
+func IsRestartable(err Error) bool {
+	return IsRetryable(err) || Labels(err).Has(LabelPanic)
+}
+
+// backoff returns the delay before the restart following n restarts within the window.
+func (self SupervisorPolicy) backoff(n int) time.Duration {
+	var delay = self.MinBackoff
+
+	for i := 1; i < n && delay < self.MaxBackoff; i++ {
+		// doubling stops at MaxBackoff, so that the delay never overflows
+		if delay > self.MaxBackoff/2 {
+			return self.MaxBackoff
+		}
+
+		delay *= 2
+	}
+
+	return min(delay, self.MaxBackoff)
+}
+
+// Supervise runs a long-running worker and restarts it according to the policy.
+// Panics are converted into errors. It returns nil when the worker returns nil or ctx is done,
+// the error of the worker when it is not restartable and ErrWorkerEscalated when the worker restarts too often.
+// Names of running workers are unique, ErrWorkerExists is returned for a name which is still supervised.
+// Statuses of finished workers are kept until the name is supervised again or ForgetWorker is called.
+func Supervise(ctx context.Context, name string, worker func(context.Context) error, policy SupervisorPolicy) error {
+	policy = policy.withDefaults()
+
+	var status, ok = supervised.register(name)
+	if !ok {
+		return ErrWorkerExists.New(name)
+	}
+
+	defer supervised.finish(status)
+
+	var restarts []time.Time
+
+	for {
+		status.update(func(s *WorkerStatus) {
+			s.State = WorkerRunning
+			s.StartedAt = time.Now()
+		})
+
+		var err = From(Catch(func() error { return worker(ctx) }))
+
+		if err == nil || ctx.Err() != nil {
+			status.failed(WorkerStopped, err)
+			return nil
+		}
+
+		if !policy.Restart(err) {
+			status.failed(WorkerFailed, err)
+			return err
+		}
+
+		var now = time.Now()
+
+		restarts = append(slices.DeleteFunc(restarts, func(t time.Time) bool {
+			return now.Sub(t) > policy.Window
+		}), now)
+
+		if policy.MaxRestarts > 0 && len(restarts) > policy.MaxRestarts {
+			status.failed(WorkerEscalated, err)
+			return ErrWorkerEscalated.New(name, len(restarts)-1, policy.Window).Wrap(err)
+		}
+
+		status.failed(WorkerBackoff, err)
+
+		var timer = time.NewTimer(policy.backoff(len(restarts)))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			status.update(func(s *WorkerStatus) { s.State = WorkerStopped })
+
+			return nil
+		case <-timer.C:
+		}
+
+		status.update(func(s *WorkerStatus) { s.Restarts++ })
+	}
+}
+
+// Workers returns statuses of the workers sorted by name, finished ones included, see ForgetWorker.
+func Workers() []WorkerStatus {
+	return supervised.list()
+}
+
+// Worker returns a status of the worker, the last one if it is finished.
+func Worker(name string) (WorkerStatus, bool) {
+	return supervised.get(name)
+}
+
+// ForgetWorker drops the status of a finished worker, statuses of running workers are kept.
+func ForgetWorker(name string) {
+	supervised.forget(name)
+}
+
+var supervised = &workerRegistry{
+	workers: make(map[string]*workerRecord),
+}
+
+type workerRegistry struct {
+	mu      sync.Mutex
+	workers map[string]*workerRecord
+}
+
+type workerRecord struct {
+	mu      sync.Mutex
+	status  WorkerStatus
+	running bool // guarded by the registry mutex
+}
+
+func (self *workerRegistry) register(name string) (*workerRecord, bool) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if record, ok := self.workers[name]; ok && record.running {
+		return nil, false
+	}
+
+	// a status of the finished worker of the same name is replaced
+	var record = &workerRecord{status: WorkerStatus{Name: name}, running: true}
+	self.workers[name] = record
+
+	return record, true
+}
+
+func (self *workerRegistry) finish(record *workerRecord) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	record.running = false
+}
+
+func (self *workerRegistry) forget(name string) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if record, ok := self.workers[name]; ok && !record.running {
+		delete(self.workers, name)
+	}
+}
+
+func (self *workerRegistry) get(name string) (WorkerStatus, bool) {
+	self.mu.Lock()
+	var record, ok = self.workers[name]
+	self.mu.Unlock()
+
+	if !ok {
+		return WorkerStatus{}, false
+	}
+
+	record.mu.Lock()
+	defer record.mu.Unlock()
+
+	return record.status, true
+}
+
+func (self *workerRegistry) list() []WorkerStatus {
+	self.mu.Lock()
+	var records = make([]*workerRecord, 0, len(self.workers))
+	for _, r := range self.workers {
+		records = append(records, r)
+	}
+	self.mu.Unlock()
+
+	var out = make([]WorkerStatus, 0, len(records))
+	for _, r := range records {
+		r.mu.Lock()
+		out = append(out, r.status)
+		r.mu.Unlock()
+	}
+
+	slices.SortFunc(out, func(a, b WorkerStatus) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return out
+}
+
+func (self *workerRecord) update(fn func(*WorkerStatus)) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	fn(&self.status)
+}
+
+func (self *workerRecord) failed(state WorkerState, err Error) {
+	self.update(func(s *WorkerStatus) {
+		s.State = state
+
+		if err != nil {
+			s.LastError = err
+			s.LastErrorAt = time.Now()
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package errors
+
+import (
+	"context"
+	"time"
+)
+
+var testPolicy = SupervisorPolicy{
+	MinBackoff:  time.Millisecond,
+	MaxBackoff:  2 * time.Millisecond,
+	MaxRestarts: 3,
+	Window:      time.Minute,
+}
+
+func (suite *ErrorsSuite) TestSuperviseRestart() {
+	var (
+		calls  int
+		status WorkerStatus
+	)
+
+	var err = Supervise(context.Background(), "restart", func(context.Context) error {
+		if calls++; calls < 3 {
+			return NewTimeoutError("timed out")
+		}
+
+		status, _ = Worker("restart")
+
+		return nil
+	}, testPolicy)
+
+	suite.Require().NoError(err)
+	suite.Require().Equal(3, calls)
+
+	suite.Require().Equal(WorkerRunning, status.State)
+	suite.Require().Equal(2, status.Restarts)
+	suite.Require().Equal(ErrKindTimeout, KindOf(status.LastError))
+
+	var ok bool
+	status, ok = Worker("restart")
+	suite.Require().True(ok)
+	suite.Require().Equal(WorkerStopped, status.State)
+	suite.Require().Equal(2, status.Restarts)
+
+	ForgetWorker("restart")
+	_, ok = Worker("restart")
+	suite.Require().False(ok)
+}
+
+func (suite *ErrorsSuite) TestSupervisePermanent() {
+	for _, permanent := range []Error{
+		NewAuthenticationError("invalid token"),
+		NewValidationError("invalid config"),
+		NewNotFoundError("queue not found"),
+	} {
+		var calls int
+
+		var err = Supervise(context.Background(), "permanent", func(context.Context) error {
+			calls++
+			return permanent
+		}, testPolicy)
+
+		suite.Require().Equal(permanent, err)
+		suite.Require().Equal(1, calls)
+
+		// the worker is finished, so its status is kept and its name is free
+		var status, _ = Worker("permanent")
+		suite.Require().Equal(WorkerFailed, status.State)
+		suite.Require().Equal(permanent, status.LastError)
+	}
+
+	ForgetWorker("permanent")
+
+	suite.Require().True(IsRestartable(NewNotFoundError("kek").WithLabels(LabelRetryable)))
+}
+
+func (suite *ErrorsSuite) TestSuperviseEscalate() {
+	var (
+		calls  int
+		status WorkerStatus
+	)
+
+	var err = Supervise(context.Background(), "escalate", func(context.Context) error {
+		status, _ = Worker("escalate")
+		calls++
+		panic("kek")
+	}, testPolicy)
+
+	suite.Require().True(Is(err, ErrWorkerEscalated))
+	suite.Require().Equal(ErrKindInfrastructure, KindOf(err))
+	suite.Require().Equal(4, calls)
+	suite.Require().Contains(Raw(err).Error(), "panic: kek")
+
+	suite.Require().Equal(3, status.Restarts)
+	suite.Require().True(status.LastError.Labels().Has(LabelPanic))
+
+	status, _ = Worker("escalate")
+	suite.Require().Equal(WorkerEscalated, status.State)
+	suite.Require().Equal(3, status.Restarts)
+	ForgetWorker("escalate")
+}
+
+func (suite *ErrorsSuite) TestSuperviseBackoff() {
+	var policy = SupervisorPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Minute}.withDefaults()
+
+	suite.Require().Equal(100*time.Millisecond, policy.backoff(1))
+	suite.Require().Equal(200*time.Millisecond, policy.backoff(2))
+	suite.Require().Equal(51200*time.Millisecond, policy.backoff(10))
+	suite.Require().Equal(time.Minute, policy.backoff(11))
+	suite.Require().Equal(time.Minute, policy.backoff(38))
+	suite.Require().Equal(time.Minute, policy.backoff(1000))
+
+	policy = SupervisorPolicy{MinBackoff: time.Second, MaxBackoff: time.Duration(1<<63 - 1)}
+	suite.Require().Positive(policy.backoff(100))
+}
+
+func (suite *ErrorsSuite) TestSuperviseCancel() {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		started     = make(chan struct{})
+		done        = make(chan error)
+	)
+
+	go func() {
+		done <- Supervise(ctx, "cancel", func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return NewTimeoutError("canceled")
+		}, testPolicy)
+	}()
+
+	<-started
+
+	var status, _ = Worker("cancel")
+	suite.Require().Equal(WorkerRunning, status.State)
+
+	var names []string
+	for _, w := range Workers() {
+		names = append(names, w.Name)
+	}
+
+	suite.Require().Contains(names, "cancel")
+
+	var err = Supervise(ctx, "cancel", func(context.Context) error { return nil }, testPolicy)
+	suite.Require().True(Is(err, ErrWorkerExists))
+
+	// running workers are not forgotten
+	ForgetWorker("cancel")
+	var _, ok = Worker("cancel")
+	suite.Require().True(ok)
+
+	cancel()
+	suite.Require().NoError(<-done)
+
+	status, _ = Worker("cancel")
+	suite.Require().Equal(WorkerStopped, status.State)
+
+	ForgetWorker("cancel")
+	_, ok = Worker("cancel")
+	suite.Require().False(ok)
+}
@@ -0,0 +1,68 @@
+package errors
+
+import (
+	"context"
+
+	"github.com/aerario/errors/tracing"
+)
+
+const (
+	DetailTraceId = "trace_id"
+	DetailSpanId  = "span_id"
+)
+
+// RecordOnSpan records an exception event on the span following OpenTelemetry semantic conventions.
+// Span status is set to error for server faults only, client faults are not failures of the span.
+func RecordOnSpan(span tracing.Span, err error) {
+	if span == nil || err == nil {
+		return
+	}
+
+	var (
+		kind     = KindOf(err)
+		excType  = kindName(kind)
+		raw      = Raw(err)
+		excTrace string
+	)
+
+	if code := Code(err); code != excType {
+		excType += ":" + code
+	}
+
+	if t, ok := raw.(Stacker); ok {
+		excTrace = string(t.StackTrace())
+	}
+
+	span.RecordError(err,
+		tracing.Attribute{Key: tracing.ExceptionType, Value: excType},
+		tracing.Attribute{Key: tracing.ExceptionMessage, Value: raw.Error()},
+		tracing.Attribute{Key: tracing.ExceptionStacktrace, Value: excTrace},
+	)
+
+	span.SetAttributes(tracing.Attribute{Key: tracing.ErrorType, Value: excType})
+
+	if IsServerFault(kind) {
+		span.SetStatus(tracing.StatusError, err.Error())
+	}
+}
+
+// RecordOnSpanContext returns a copy of the error with trace and span ids found in the context
+// attached to its details and records the copy on the span. The error itself is left untouched.
+func RecordOnSpanContext(ctx context.Context, span tracing.Span, err error) Error {
+	if err == nil {
+		return nil
+	}
+
+	var out Error = From(err).(*implementation).clone()
+
+	if traceId, spanId, ok := tracing.IDsFromContext(ctx); ok {
+		out = out.WithDetails(map[string]string{
+			DetailTraceId: traceId,
+			DetailSpanId:  spanId,
+		})
+	}
+
+	RecordOnSpan(span, out)
+
+	return out
+}
@@ -0,0 +1,100 @@
+// Package tracing defines a minimal span interface errors are recorded on.
+// It does not depend on any tracing SDK, an OpenTelemetry span is adapted with a thin shim:
+//
+//	type otelSpan struct{ trace.Span }
+//
+//	func (s otelSpan) RecordError(err error, attrs ...tracing.Attribute) {
+//		var kv = make([]attribute.KeyValue, 0, len(attrs))
+//		for _, a := range attrs {
+//			kv = append(kv, attribute.String(a.Key, a.Value))
+//		}
+//		s.Span.AddEvent(tracing.ExceptionEvent, trace.WithAttributes(kv...))
+//	}
+//
+//	func (s otelSpan) SetStatus(code tracing.StatusCode, description string) {
+//		s.Span.SetStatus(codes.Code(code), description)
+//	}
+//
+//	func (s otelSpan) SetAttributes(attrs ...tracing.Attribute) { ... }
+//
+// Trace and span ids are read out of a context with an extractor set by SetIDExtractor:
+//
+//	tracing.SetIDExtractor(func(ctx context.Context) (string, string, bool) {
+//		var sc = trace.SpanContextFromContext(ctx)
+//		return sc.TraceID().String(), sc.SpanID().String(), sc.IsValid()
+//	})
+package tracing
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// OpenTelemetry semantic conventions for errors and exceptions.
+const (
+	ErrorType           = "error.type"
+	ExceptionEvent      = "exception"
+	ExceptionType       = "exception.type"
+	ExceptionMessage    = "exception.message"
+	ExceptionStacktrace = "exception.stacktrace"
+)
+
+// StatusCode mirrors OpenTelemetry status codes.
+type StatusCode uint32
+
+const (
+	StatusUnset StatusCode = iota
+	StatusError
+	StatusOK
+)
+
+type Attribute struct {
+	Key   string
+	Value string
+}
+
+// Span is a subset of OpenTelemetry span methods.
+type Span interface {
+	// RecordError records an exception event with the given attributes.
+	RecordError(err error, attributes ...Attribute)
+	SetStatus(code StatusCode, description string)
+	SetAttributes(attributes ...Attribute)
+}
+
+type idsKey struct{}
+
+type ids struct {
+	traceId string
+	spanId  string
+}
+
+// ContextWithIDs stores trace and span ids in a context.
+func ContextWithIDs(ctx context.Context, traceId, spanId string) context.Context {
+	return context.WithValue(ctx, idsKey{}, ids{traceId: traceId, spanId: spanId})
+}
+
+var extractor atomic.Pointer[func(context.Context) (string, string, bool)]
+
+// SetIDExtractor sets a function reading trace and span ids of a tracing SDK,
+// it is consulted when there are no ids stored by ContextWithIDs.
+func SetIDExtractor(fn func(ctx context.Context) (traceId, spanId string, ok bool)) {
+	if fn == nil {
+		extractor.Store(nil)
+		return
+	}
+
+	extractor.Store(&fn)
+}
+
+// IDsFromContext returns trace and span ids found in the context.
+func IDsFromContext(ctx context.Context) (traceId, spanId string, ok bool) {
+	if t, ok := ctx.Value(idsKey{}).(ids); ok {
+		return t.traceId, t.spanId, true
+	}
+
+	if fn := extractor.Load(); fn != nil {
+		return (*fn)(ctx)
+	}
+
+	return "", "", false
+}
@@ -0,0 +1,35 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TracingSuite struct {
+	suite.Suite
+}
+
+func TestTracingSuite(t *testing.T) {
+	suite.Run(t, new(TracingSuite))
+}
+
+func (suite *TracingSuite) TestIDsFromContext() {
+	var _, _, ok = IDsFromContext(context.Background())
+	suite.Require().False(ok)
+
+	SetIDExtractor(func(context.Context) (string, string, bool) { return "sdk-trace", "sdk-span", true })
+	defer SetIDExtractor(nil)
+
+	var traceId, spanId string
+	traceId, spanId, ok = IDsFromContext(context.Background())
+	suite.Require().True(ok)
+	suite.Require().Equal("sdk-trace", traceId)
+	suite.Require().Equal("sdk-span", spanId)
+
+	traceId, spanId, ok = IDsFromContext(ContextWithIDs(context.Background(), "trace", "span"))
+	suite.Require().True(ok)
+	suite.Require().Equal("trace", traceId)
+	suite.Require().Equal("span", spanId)
+}
@@ -0,0 +1,66 @@
+package errors
+
+import (
+	"context"
+
+	"github.com/aerario/errors/tracing"
+)
+
+type spanMock struct {
+	recorded   error
+	attributes []tracing.Attribute
+	status     tracing.StatusCode
+}
+
+func (self *spanMock) RecordError(err error, attributes ...tracing.Attribute) {
+	self.recorded = err
+	self.attributes = append(self.attributes, attributes...)
+}
+
+func (self *spanMock) SetStatus(code tracing.StatusCode, _ string) {
+	self.status = code
+}
+
+func (self *spanMock) SetAttributes(attributes ...tracing.Attribute) {
+	self.attributes = append(self.attributes, attributes...)
+}
+
+func (suite *ErrorsSuite) TestRecordOnSpan() {
+	var (
+		span = &spanMock{}
+		err  = WithCode(NewNotFoundFactory("user %d not found"), "USER-404").New(1)
+	)
+
+	RecordOnSpan(span, err)
+	suite.Require().Equal(err, span.recorded)
+	suite.Require().Equal(tracing.StatusUnset, span.status)
+	suite.Require().Len(span.attributes, 4)
+	suite.Require().Equal(tracing.Attribute{Key: tracing.ExceptionType, Value: "NotFound:USER-404"}, span.attributes[0])
+	suite.Require().Equal(tracing.Attribute{Key: tracing.ExceptionMessage, Value: "user 1 not found"}, span.attributes[1])
+	suite.Require().Equal(tracing.ExceptionStacktrace, span.attributes[2].Key)
+	suite.Require().Contains(span.attributes[2].Value, `"kind":"NotFound"`)
+	suite.Require().Equal(tracing.Attribute{Key: tracing.ErrorType, Value: "NotFound:USER-404"}, span.attributes[3])
+
+	span = &spanMock{}
+	RecordOnSpan(span, NewInfrastructureError("no connection"))
+	suite.Require().Equal(tracing.StatusError, span.status)
+	suite.Require().Equal(tracing.Attribute{Key: tracing.ExceptionType, Value: "Infrastructure"}, span.attributes[0])
+}
+
+func (suite *ErrorsSuite) TestRecordOnSpanContext() {
+	var (
+		span   = &spanMock{}
+		ctx    = tracing.ContextWithIDs(context.Background(), "trace", "span")
+		source = NewTimeoutError("timed out").WithDetails(map[string]string{"kek": "lol"})
+		err    = RecordOnSpanContext(ctx, span, source)
+	)
+
+	suite.Require().Equal(map[string]string{"kek": "lol", DetailTraceId: "trace", DetailSpanId: "span"}, err.Details())
+	suite.Require().Equal(err, span.recorded)
+	suite.Require().Equal(map[string]string{"kek": "lol"}, source.Details())
+	suite.Require().True(Is(err, source))
+	suite.Require().Equal(source.(Stacker).Location(), err.(Stacker).Location())
+	suite.Require().Equal(tracing.StatusError, span.status)
+
+	suite.Require().Nil(RecordOnSpanContext(ctx, span, nil))
+}
@@ -0,0 +1,98 @@
+package errors
+
+// Translator maps errors of a downstream service to local factories.
+// Rules are checked in the order they were added, the first matching one wins.
+// Translated errors keep the original error as a cause, but only the local message is user-friendly.
+type Translator struct {
+	rules    []translationRule
+	fallback Factory
+}
+
+type translationRule struct {
+	match   func(error) bool
+	factory Factory
+}
+
+// NewTranslator returns a translator which errors not matching any rule are made by fallback,
+// nil fallback makes it return unmatched errors as is.
+func NewTranslator(fallback Factory) *Translator {
+	return &Translator{fallback: fallback}
+}
+
+// Code maps errors with the code to the factory.
+func (self *Translator) Code(code string, factory Factory) *Translator {
+	return self.Match(func(err error) bool { return Code(err) == code }, factory)
+}
+
+// Kind maps errors of the kind to the factory.
+func (self *Translator) Kind(kind Kind, factory Factory) *Translator {
+	return self.Match(func(err error) bool { return KindOf(err) == kind }, factory)
+}
+
+// Match maps errors matching the predicate to the factory.
+func (self *Translator) Match(match func(error) bool, factory Factory) *Translator {
+	self.rules = append(self.rules, translationRule{match: match, factory: factory})
+	return self
+}
+
+// Include appends rules of other translators, their fallbacks are ignored.
+func (self *Translator) Include(others ...*Translator) *Translator {
+	for _, other := range others {
+		self.rules = append(self.rules, other.rules...)
+	}
+
+	return self
+}
+
+// Translate returns a local error made out of err, nil errors stay nil.
+// Templates of factories made by this package are given the message of err if they have verbs, e.g. "upstream: %s".
+func (self *Translator) Translate(err error) Error {
+	if err == nil {
+		return nil
+	}
+
+	var target = self.fallback
+
+	for _, rule := range self.rules {
+		if rule.match(err) {
+			target = rule.factory
+			break
+		}
+	}
+
+	if target == nil {
+		return From(err)
+	}
+
+	var out *implementation
+
+	if f, ok := target.(*factory); ok {
+		var args []interface{}
+		if hasVerbs(f.template) {
+			args = []interface{}{err.Error()}
+		}
+
+		out = f.make(args)
+		out.construct(nil, f.stack, 1)
+	} else {
+		out = From(target.NewSkip(1)).(*implementation)
+	}
+
+	out.previous = &boundary{err: err}
+	wrapped(out, err)
+
+	return out
+}
+
+// boundary hides user-friendly messages of remote errors, but keeps them reachable by Is, As and Raw.
+type boundary struct {
+	err error
+}
+
+func (self *boundary) Error() string {
+	return self.err.Error()
+}
+
+func (self *boundary) Unwrap() error {
+	return self.err
+}
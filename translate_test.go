@@ -0,0 +1,87 @@
+package errors
+
+import (
+	"encoding/json/v2"
+	"errors"
+)
+
+var (
+	errAccountMissing = NewNotFoundFactory("account is missing")
+	errAccountExists  = NewAlreadyExistsFactory("account already exists")
+	errUpstream       = NewThirdPartiesFactory("accounts service is unavailable")
+	errAccessDenied   = NewAuthorizationFactory("access denied")
+)
+
+func (suite *ErrorsSuite) TestTranslator() {
+	var (
+		common = NewTranslator(nil).
+			Kind(ErrKindAuthorization, errAccessDenied)
+		translator = NewTranslator(errUpstream).
+				Code("USER-404", errAccountMissing).
+				Match(func(err error) bool { return Labels(err).Has("duplicate") }, errAccountExists).
+				Include(common)
+		remoteMissing = WithCode(NewNotFoundFactory("user %d not found"), "USER-404")
+	)
+
+	var tests = []struct {
+		name string
+		err  error
+		want Factory
+	}{
+		{name: "code", err: remoteMissing.New(1), want: errAccountMissing},
+		{name: "predicate", err: NewBadRequestFactory("duplicate").WithLabels("duplicate").New(), want: errAccountExists},
+		{name: "included", err: NewAuthorizationError("forbidden"), want: errAccessDenied},
+		{name: "fallback kind", err: NewNotFoundError("user not found"), want: errUpstream},
+		{name: "fallback go error", err: errors.New("connection reset"), want: errUpstream},
+	}
+
+	for _, t := range tests {
+		suite.Run(t.name, func() {
+			var err = translator.Translate(t.err)
+			suite.Require().True(Is(err, t.want))
+			suite.Require().True(Is(err, t.err))
+			suite.Require().Equal(t.want.New().Error(), err.Error())
+			suite.requireLocation(err, "translate_test.go")
+		})
+	}
+
+	suite.Require().Nil(translator.Translate(nil))
+}
+
+func (suite *ErrorsSuite) TestTranslatorCause() {
+	var (
+		remote = WithCode(NewNotFoundFactory("user %d not found"), "USER-404").New(1)
+		err    = NewTranslator(nil).Code("USER-404", errAccountMissing).Translate(remote)
+	)
+
+	suite.Require().Equal("account is missing", err.Error())
+	suite.Require().Equal("account is missing: user 1 not found", Raw(err).Error())
+
+	var cause Error
+	suite.Require().True(As(err.Unwrap(), &cause))
+	suite.Require().Equal(remote, cause)
+
+	var frames []stackTraceFrame
+	suite.Require().NoError(json.Unmarshal(err.(Stacker).StackTrace(), &frames))
+	suite.Require().Len(frames, 2)
+	suite.Require().Equal("NotFound", frames[1].Kind)
+	suite.Require().Equal("user 1 not found", frames[1].Error)
+
+	// there is no fallback, unmatched errors are returned as is
+	suite.Require().Equal(remote, NewTranslator(nil).Translate(remote))
+}
+
+func (suite *ErrorsSuite) TestTranslatorTemplate() {
+	var (
+		upstream = NewThirdPartiesFactory("accounts service: %s")
+		remote   = WithCode(NewNotFoundFactory("user %d not found"), "USER-404").New(1)
+		err      = NewTranslator(upstream).Translate(remote)
+	)
+
+	suite.Require().Equal("accounts service: user 1 not found", err.Error())
+	suite.Require().True(Is(err, upstream))
+
+	err = NewTranslator(foreignFactory{errUpstream}).Translate(remote)
+	suite.Require().Equal("accounts service is unavailable", err.Error())
+	suite.requireLocation(err, "translate_test.go")
+}